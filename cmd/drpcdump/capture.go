@@ -0,0 +1,360 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// flow is the reassembled bytes sent in one direction of a connection.
+type flow struct {
+	conn string // identifies the connection the flow is part of
+	src  string
+	dst  string
+	data []byte
+	segs []segment // the time at which data was received, by offset
+	next uint32    // the next expected sequence number
+	seen bool      // true if next is valid
+	ooo  map[uint32][]byte
+}
+
+// segment records that the bytes starting at the offset arrived at the time.
+type segment struct {
+	off  int
+	time time.Time
+}
+
+// timeAt returns the time at which the byte at the offset arrived.
+func (f *flow) timeAt(off int) time.Time {
+	i := sort.Search(len(f.segs), func(i int) bool { return f.segs[i].off > off })
+	if i == 0 {
+		return time.Time{}
+	}
+	return f.segs[i-1].time
+}
+
+// isCapture returns true if the data starts with a pcap or pcapng header.
+func isCapture(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	switch binary.LittleEndian.Uint32(data) {
+	case 0xa1b2c3d4, 0xd4c3b2a1, 0xa1b23c4d, 0x4d3cb2a1, 0x0a0d0d0a:
+		return true
+	}
+	return false
+}
+
+// readCapture extracts the TCP flows with any payload from a pcap or pcapng
+// capture, in the order they were first seen.
+func readCapture(data []byte) ([]*flow, error) {
+	a := &assembler{flows: make(map[string]*flow)}
+
+	var err error
+	if binary.LittleEndian.Uint32(data) == 0x0a0d0d0a {
+		err = readPcapng(data, a.packet)
+	} else {
+		err = readPcap(data, a.packet)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var flows []*flow
+	for _, f := range a.order {
+		if len(f.data) > 0 {
+			flows = append(flows, f)
+		}
+	}
+	return flows, nil
+}
+
+// readPcap calls cb with the link type, timestamp and data of every packet in
+// a pcap capture.
+func readPcap(data []byte, cb func(link uint32, ts time.Time, pkt []byte)) error {
+	if len(data) < 24 {
+		return errs.New("truncated pcap header")
+	}
+
+	var bo binary.ByteOrder = binary.LittleEndian
+	magic := bo.Uint32(data)
+	if magic == 0xd4c3b2a1 || magic == 0x4d3cb2a1 {
+		bo, magic = binary.BigEndian, binary.BigEndian.Uint32(data)
+	}
+	nanos := magic == 0xa1b23c4d
+	link := bo.Uint32(data[20:]) & 0xffff
+
+	for data = data[24:]; len(data) >= 16; {
+		sec, frac := bo.Uint32(data), bo.Uint32(data[4:])
+		incl := int(bo.Uint32(data[8:]))
+		if incl > len(data)-16 {
+			return errs.New("truncated pcap record")
+		}
+
+		if !nanos {
+			frac *= 1000
+		}
+		cb(link, time.Unix(int64(sec), int64(frac)).UTC(), data[16:16+incl])
+		data = data[16+incl:]
+	}
+	return nil
+}
+
+// readPcapng calls cb with the link type, timestamp and data of every packet
+// in a pcapng capture.
+func readPcapng(data []byte, cb func(link uint32, ts time.Time, pkt []byte)) error {
+	type iface struct {
+		link uint32
+		res  uint64 // timestamp units per second
+	}
+
+	var bo binary.ByteOrder = binary.LittleEndian
+	var ifaces []iface
+
+	for len(data) >= 12 {
+		if binary.LittleEndian.Uint32(data) == 0x0a0d0d0a {
+			// the section header block determines the byte order.
+			if binary.LittleEndian.Uint32(data[8:]) == 0x1a2b3c4d {
+				bo = binary.LittleEndian
+			} else {
+				bo = binary.BigEndian
+			}
+			ifaces = nil
+		}
+
+		typ, size := bo.Uint32(data), int(bo.Uint32(data[4:]))
+		if size < 12 || size > len(data) {
+			return errs.New("truncated pcapng block")
+		}
+		body := data[8 : size-4]
+		data = data[size:]
+
+		switch typ {
+		case 1: // interface description block
+			if len(body) < 8 {
+				return errs.New("truncated pcapng interface block")
+			}
+			ifc := iface{link: uint32(bo.Uint16(body)), res: 1e6}
+			for opts := body[8:]; len(opts) >= 4; {
+				code, olen := bo.Uint16(opts), int(bo.Uint16(opts[2:]))
+				if code == 0 || 4+olen > len(opts) {
+					break
+				}
+				if code == 9 && olen >= 1 { // if_tsresol
+					ifc.res = tsresol(opts[4])
+				}
+				opts = opts[4+(olen+3)&^3:]
+			}
+			ifaces = append(ifaces, ifc)
+
+		case 6: // enhanced packet block
+			if len(body) < 20 {
+				return errs.New("truncated pcapng packet block")
+			}
+			id := int(bo.Uint32(body))
+			if id >= len(ifaces) {
+				return errs.New("unknown pcapng interface %d", id)
+			}
+			ts := uint64(bo.Uint32(body[4:]))<<32 | uint64(bo.Uint32(body[8:]))
+			incl := int(bo.Uint32(body[12:]))
+			if incl > len(body)-20 {
+				return errs.New("truncated pcapng packet block")
+			}
+			ifc := ifaces[id]
+			sec, frac := ts/ifc.res, ts%ifc.res
+			cb(ifc.link, time.Unix(int64(sec), int64(frac*1e9/ifc.res)).UTC(), body[20:20+incl])
+		}
+	}
+	return nil
+}
+
+// tsresol returns the timestamp units per second for an if_tsresol value.
+func tsresol(v byte) uint64 {
+	res := uint64(1)
+	for i := byte(0); i < v&0x7f; i++ {
+		if v&0x80 != 0 {
+			res *= 2
+		} else {
+			res *= 10
+		}
+	}
+	return res
+}
+
+// assembler reassembles the TCP flows from captured packets.
+type assembler struct {
+	flows map[string]*flow
+	order []*flow
+}
+
+// packet handles a captured link layer packet.
+func (a *assembler) packet(link uint32, ts time.Time, pkt []byte) {
+	var ethertype uint16
+
+	switch link {
+	case 0: // BSD loopback with a host order address family
+		if len(pkt) < 4 {
+			return
+		}
+		family := binary.LittleEndian.Uint32(pkt)
+		if family > 0xffff {
+			family = binary.BigEndian.Uint32(pkt)
+		}
+		ethertype, pkt = 0x0800, pkt[4:]
+		if family != 2 {
+			ethertype = 0x86dd
+		}
+
+	case 1: // ethernet
+		if len(pkt) < 14 {
+			return
+		}
+		ethertype, pkt = binary.BigEndian.Uint16(pkt[12:]), pkt[14:]
+		for ethertype == 0x8100 && len(pkt) >= 4 { // vlan tags
+			ethertype, pkt = binary.BigEndian.Uint16(pkt[2:]), pkt[4:]
+		}
+
+	case 101: // raw ip
+		if len(pkt) < 1 {
+			return
+		}
+		ethertype = 0x0800
+		if pkt[0]>>4 == 6 {
+			ethertype = 0x86dd
+		}
+
+	case 113: // linux cooked capture
+		if len(pkt) < 16 {
+			return
+		}
+		ethertype, pkt = binary.BigEndian.Uint16(pkt[14:]), pkt[16:]
+
+	case 276: // linux cooked capture v2
+		if len(pkt) < 20 {
+			return
+		}
+		ethertype, pkt = binary.BigEndian.Uint16(pkt), pkt[20:]
+
+	default:
+		return
+	}
+
+	var src, dst net.IP
+	switch ethertype {
+	case 0x0800:
+		if len(pkt) < 20 || pkt[9] != 6 {
+			return
+		}
+		ihl := int(pkt[0]&0xf) * 4
+		total := int(binary.BigEndian.Uint16(pkt[2:]))
+		if ihl < 20 || total < ihl || total > len(pkt) {
+			return
+		}
+		src, dst, pkt = net.IP(pkt[12:16]), net.IP(pkt[16:20]), pkt[ihl:total]
+
+	case 0x86dd:
+		if len(pkt) < 40 || pkt[6] != 6 {
+			return
+		}
+		plen := int(binary.BigEndian.Uint16(pkt[4:]))
+		if 40+plen > len(pkt) {
+			return
+		}
+		src, dst, pkt = net.IP(pkt[8:24]), net.IP(pkt[24:40]), pkt[40:40+plen]
+
+	default:
+		return
+	}
+
+	if len(pkt) < 20 {
+		return
+	}
+	sport, dport := binary.BigEndian.Uint16(pkt), binary.BigEndian.Uint16(pkt[2:])
+	seq := binary.BigEndian.Uint32(pkt[4:])
+	off, flags := int(pkt[12]>>4)*4, pkt[13]
+	if off < 20 || off > len(pkt) {
+		return
+	}
+
+	a.segment(
+		net.JoinHostPort(src.String(), fmt.Sprint(sport)),
+		net.JoinHostPort(dst.String(), fmt.Sprint(dport)),
+		ts, seq, flags&0x02 != 0, pkt[off:])
+}
+
+// segment adds a TCP segment to the flow from src to dst.
+func (a *assembler) segment(src, dst string, ts time.Time, seq uint32, syn bool, payload []byte) {
+	key := src + " " + dst
+	f := a.flows[key]
+	if f == nil || (syn && len(f.data) > 0) {
+		// a new syn after data means the ports were reused for a new
+		// connection, so start a new flow.
+		conn := src + " " + dst
+		if src > dst {
+			conn = dst + " " + src
+		}
+		f = &flow{conn: fmt.Sprintf("%s #%d", conn, len(a.order)), src: src, dst: dst, ooo: make(map[uint32][]byte)}
+		if rev := a.flows[dst+" "+src]; rev != nil {
+			f.conn = rev.conn
+		}
+		a.flows[key] = f
+		a.order = append(a.order, f)
+	}
+
+	if syn {
+		f.next, f.seen = seq+1, true
+		return
+	}
+	if !f.seen {
+		f.next, f.seen = seq, true
+	}
+	if len(payload) == 0 {
+		return
+	}
+
+	// trim any bytes that were already received, like from retransmits.
+	if diff := int32(f.next - seq); diff > 0 {
+		if int(diff) >= len(payload) {
+			return
+		}
+		seq, payload = f.next, payload[diff:]
+	}
+
+	if seq != f.next {
+		if prev, ok := f.ooo[seq]; !ok || len(prev) < len(payload) {
+			f.ooo[seq] = append([]byte(nil), payload...)
+		}
+		return
+	}
+
+	f.segs = append(f.segs, segment{off: len(f.data), time: ts})
+	f.data = append(f.data, payload...)
+	f.next += uint32(len(payload))
+
+	// deliver any buffered segments that are now in order.
+	for len(f.ooo) > 0 {
+		delivered := false
+		for oseq, opayload := range f.ooo {
+			diff := int32(f.next - oseq)
+			if diff < 0 {
+				continue
+			}
+			delete(f.ooo, oseq)
+			if int(diff) < len(opayload) {
+				f.data = append(f.data, opayload[diff:]...)
+				f.next += uint32(len(opayload) - int(diff))
+			}
+			delivered = true
+		}
+		if !delivered {
+			break
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"storj.io/drpc/drpcmigrate"
+	"storj.io/drpc/drpcwire"
+)
+
+// event is a decoded frame or packet, or a note about a problem decoding.
+type event struct {
+	time time.Time
+	flow *flow
+	typ  string // "frame", "packet" or "note"
+	fr   drpcwire.Frame
+	pkt  drpcwire.Packet
+	note string
+}
+
+// decodeFlow parses the frames in the flow with drpcwire.ParseFrame and
+// reassembles the packets with a drpcwire.Reader. Frame events are only
+// included if frames is true.
+func decodeFlow(f *flow, frames bool) (evs []event) {
+	data, base := f.data, 0
+	if bytes.HasPrefix(data, []byte(drpcmigrate.DRPCHeader)) {
+		base = len(drpcmigrate.DRPCHeader)
+		data = data[base:]
+	}
+
+	rd := drpcwire.NewReaderWithOptions(bytes.NewReader(data), drpcwire.ReaderOptions{
+		MaximumBufferSize: len(data) + 1,
+	})
+	note := func(off int, msg string) {
+		evs = append(evs, event{time: f.timeAt(base + off), flow: f, typ: "note", note: msg})
+	}
+
+	for rem := data; len(rem) > 0; {
+		next, fr, ok, err := drpcwire.ParseFrame(rem)
+		off := len(data) - len(next)
+		switch {
+		case err != nil:
+			note(len(data)-len(rem), "invalid frame: "+err.Error())
+			return evs
+		case !ok:
+			note(len(data)-len(rem), "truncated frame")
+			return evs
+		}
+		rem = next
+
+		// frames are timestamped with the arrival of their last byte.
+		ts := f.timeAt(base + off - 1)
+		if frames {
+			evs = append(evs, event{time: ts, flow: f, typ: "frame", fr: fr})
+		}
+		if !fr.Done {
+			continue
+		}
+
+		pkt, err := rd.ReadPacket()
+		if errors.Is(err, io.EOF) {
+			return evs
+		} else if err != nil {
+			note(off, "invalid packet: "+err.Error())
+			return evs
+		}
+		evs = append(evs, event{time: ts, flow: f, typ: "packet", pkt: pkt})
+	}
+
+	return evs
+}
+
+// mergeEvents returns the events of every flow ordered by time, keeping the
+// order of events within each flow.
+func mergeEvents(flows [][]event) []event {
+	var all []event
+	for _, evs := range flows {
+		all = append(all, evs...)
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].time.Before(all[j].time) })
+	return all
+}
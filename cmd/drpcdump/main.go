@@ -0,0 +1,129 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// drpcdump decodes captured drpc connections.
+//
+// It reads pcap or pcapng captures of TCP connections, or raw dumps of the
+// bytes sent in one direction of a connection, and prints the frames and
+// reassembled packets in them. Packets are annotated with their kind, stream
+// and message IDs, the rpc of their stream, any metadata and any error codes.
+// Messages are decoded into JSON when descriptor sets produced by
+// protoc --descriptor_set_out are provided for their services.
+//
+// Usage:
+//
+//	drpcdump [flags] file...
+//
+// Examples:
+//
+//	tcpdump -i lo -w capture.pcap 'tcp port 8080'
+//	drpcdump capture.pcap
+//	drpcdump -frames -json -protoset service.protoset capture.pcap
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+type config struct {
+	json      bool
+	frames    bool
+	data      bool
+	protosets stringsFlag
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "drpcdump:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs drpcdump with the command line arguments.
+func run(args []string, stdout, stderr io.Writer) error {
+	var conf config
+
+	flags := flag.NewFlagSet("drpcdump", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.BoolVar(&conf.json, "json", false, "print a JSON object per line instead of text")
+	flags.BoolVar(&conf.frames, "frames", false, "print every frame in addition to the reassembled packets")
+	flags.BoolVar(&conf.data, "data", false, "include the payloads that are not decoded as messages")
+	flags.Var(&conf.protosets, "protoset", "a binary FileDescriptorSet file used to decode messages (repeatable)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: drpcdump [flags] file...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "flags:")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errs.New("at least one file is required")
+	}
+
+	ann := &annotator{data: conf.data}
+	if len(conf.protosets) > 0 {
+		files, err := loadProtosets(conf.protosets)
+		if err != nil {
+			return err
+		}
+		ann.files = files
+	}
+
+	write := writeText
+	if conf.json {
+		write = writeJSON
+	}
+
+	for _, path := range flags.Args() {
+		flows, err := readFlows(path)
+		if err != nil {
+			return err
+		}
+
+		var decoded [][]event
+		for _, f := range flows {
+			decoded = append(decoded, decodeFlow(f, conf.frames))
+		}
+
+		for _, ev := range mergeEvents(decoded) {
+			if err := write(stdout, ann.annotate(ev)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readFlows returns the flows in the file, which is either a capture or a raw
+// dump of a single flow.
+func readFlows(path string) ([]*flow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if isCapture(data) {
+		flows, err := readCapture(data)
+		if err != nil {
+			return nil, errs.New("%s: %w", path, err)
+		}
+		return flows, nil
+	}
+	return []*flow{{conn: path, src: path, data: data}}, nil
+}
+
+// stringsFlag is a flag that can be given multiple times.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string     { return strings.Join(*s, ",") }
+func (s *stringsFlag) Set(v string) error { *s = append(*s, v); return nil }
@@ -0,0 +1,218 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpchealth"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcmigrate"
+	"storj.io/drpc/drpcwire"
+)
+
+func TestRaw(t *testing.T) {
+	dir := t.TempDir()
+
+	md, err := drpcmetadata.Encode(nil, map[string]string{"key": "value"})
+	assert.NoError(t, err)
+	req, err := proto.Marshal(&drpchealth.HealthCheckRequest{Service: "svc"})
+	assert.NoError(t, err)
+
+	raw := filepath.Join(dir, "raw")
+	assert.NoError(t, os.WriteFile(raw, packets(drpcmigrate.DRPCHeader,
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 1}, Kind: drpcwire.KindInvokeMetadata, Data: md},
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 2}, Kind: drpcwire.KindInvoke, Data: []byte("/drpc.health.v1.Health/Check")},
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 3}, Kind: drpcwire.KindMessage, Data: req},
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 4}, Kind: drpcwire.KindCloseSend},
+	), 0o644))
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"-protoset", protoset(t, dir), raw}, &stdout, new(bytes.Buffer)))
+	assert.Equal(t, stdout.String(), strings.ReplaceAll(""+
+		"RAW packet s:1 m:1 InvokeMetadata size:14 metadata:map[key:value]\n"+
+		"RAW packet s:1 m:2 Invoke size:28 rpc:\"/drpc.health.v1.Health/Check\"\n"+
+		"RAW packet s:1 m:3 Message size:5 rpc:\"/drpc.health.v1.Health/Check\" decoded:{\"service\":\"svc\"}\n"+
+		"RAW packet s:1 m:4 CloseSend size:0 rpc:\"/drpc.health.v1.Health/Check\"\n",
+		"RAW", raw))
+
+	// truncated data is reported.
+	assert.NoError(t, os.WriteFile(raw, []byte{0x05, 0x01, 0x01, 0x05, 'a'}, 0o644))
+	stdout.Reset()
+	assert.NoError(t, run([]string{raw}, &stdout, new(bytes.Buffer)))
+	assert.Equal(t, stdout.String(), raw+" note: truncated frame\n")
+}
+
+func TestCapture(t *testing.T) {
+	dir := t.TempDir()
+
+	resp, err := proto.Marshal(&drpchealth.HealthCheckResponse{Status: drpchealth.HealthCheckResponse_SERVING})
+	assert.NoError(t, err)
+
+	client := packets("",
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 1}, Kind: drpcwire.KindInvoke, Data: []byte("/drpc.health.v1.Health/Check")},
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 2}, Kind: drpcwire.KindMessage, Data: nil},
+	)
+	server := packets("",
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 1}, Kind: drpcwire.KindMessage, Data: resp},
+		drpcwire.Packet{ID: drpcwire.ID{Stream: 2, Message: 1}, Kind: drpcwire.KindError,
+			Data: drpcwire.MarshalError(drpcerr.WithCode(errs.New("boom"), 5))},
+	)
+
+	// the client's data is split into segments that arrive out of order and
+	// are retransmitted.
+	var c capture
+	c.segment(1, true, 100, nil, 0x02)
+	c.segment(2, false, 500, nil, 0x12)
+	c.segment(3, true, 101+10, client[10:], 0x18)
+	c.segment(4, true, 101, client[:10], 0x18)
+	c.segment(5, true, 101, client[:10], 0x18)
+	c.segment(6, false, 501, server, 0x18)
+
+	for name, data := range map[string][]byte{"capture.pcap": c.pcap(), "capture.pcapng": c.pcapng()} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, data, 0o644))
+
+		var stdout bytes.Buffer
+		assert.NoError(t, run([]string{"-json", "-protoset", protoset(t, dir), path}, &stdout, new(bytes.Buffer)))
+
+		var got []string
+		for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+			var rec record
+			assert.NoError(t, json.Unmarshal([]byte(line), &rec))
+			assert.Equal(t, rec.Type, "packet")
+			got = append(got, strings.Join([]string{rec.Time, rec.Src, rec.Kind, rec.RPC, string(rec.Decoded)}, " "))
+			if rec.Error != nil {
+				assert.Equal(t, rec.Error.Code, 5)
+				assert.Equal(t, rec.Error.Message, "boom")
+			}
+		}
+
+		assert.DeepEqual(t, got, []string{
+			"1970-01-01T00:00:04Z 10.0.0.1:1234 Invoke /drpc.health.v1.Health/Check ",
+			"1970-01-01T00:00:04Z 10.0.0.1:1234 Message /drpc.health.v1.Health/Check {}",
+			`1970-01-01T00:00:06Z 10.0.0.2:8080 Message /drpc.health.v1.Health/Check {"status":"SERVING"}`,
+			"1970-01-01T00:00:06Z 10.0.0.2:8080 Error  ",
+		})
+	}
+}
+
+// packets returns the header followed by the packets as frames.
+func packets(header string, pkts ...drpcwire.Packet) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	wr := drpcwire.NewWriter(&buf, 0)
+	for _, pkt := range pkts {
+		_ = wr.WritePacket(pkt)
+	}
+	_ = wr.Flush()
+	return buf.Bytes()
+}
+
+// protoset writes a descriptor set for the health service and returns its
+// path.
+func protoset(t *testing.T, dir string) string {
+	set, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(drpchealth.File_storj_io_drpc_drpchealth_health_proto)},
+	})
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "health.protoset")
+	assert.NoError(t, os.WriteFile(path, set, 0o644))
+	return path
+}
+
+// capture builds pcap and pcapng captures of a connection from 10.0.0.1:1234
+// to 10.0.0.2:8080.
+type capture struct {
+	secs []uint32
+	pkts [][]byte
+}
+
+func (c *capture) segment(sec uint32, fromClient bool, seq uint32, payload []byte, flags byte) {
+	be := binary.BigEndian
+
+	src, dst := []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}
+	sport, dport := uint16(1234), uint16(8080)
+	if !fromClient {
+		src, dst, sport, dport = dst, src, dport, sport
+	}
+
+	var pkt []byte
+	pkt = append(pkt, make([]byte, 12)...)
+	pkt = be.AppendUint16(pkt, 0x0800)
+	pkt = append(pkt, 0x45, 0)
+	pkt = be.AppendUint16(pkt, uint16(20+20+len(payload)))
+	pkt = append(pkt, 0, 0, 0, 0, 64, 6, 0, 0)
+	pkt = append(pkt, src...)
+	pkt = append(pkt, dst...)
+	pkt = be.AppendUint16(pkt, sport)
+	pkt = be.AppendUint16(pkt, dport)
+	pkt = be.AppendUint32(pkt, seq)
+	pkt = be.AppendUint32(pkt, 0)
+	pkt = append(pkt, 5<<4, flags, 0xff, 0xff, 0, 0, 0, 0)
+	pkt = append(pkt, payload...)
+
+	c.secs = append(c.secs, sec)
+	c.pkts = append(c.pkts, pkt)
+}
+
+func (c *capture) pcap() (buf []byte) {
+	le := binary.LittleEndian
+	buf = le.AppendUint32(buf, 0xa1b2c3d4)
+	buf = le.AppendUint16(buf, 2)
+	buf = le.AppendUint16(buf, 4)
+	buf = append(buf, make([]byte, 8)...)
+	buf = le.AppendUint32(buf, 65535)
+	buf = le.AppendUint32(buf, 1) // ethernet
+
+	for i, pkt := range c.pkts {
+		buf = le.AppendUint32(buf, c.secs[i])
+		buf = le.AppendUint32(buf, 0)
+		buf = le.AppendUint32(buf, uint32(len(pkt)))
+		buf = le.AppendUint32(buf, uint32(len(pkt)))
+		buf = append(buf, pkt...)
+	}
+	return buf
+}
+
+func (c *capture) pcapng() (buf []byte) {
+	le := binary.LittleEndian
+	block := func(typ uint32, body []byte) {
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+		buf = le.AppendUint32(buf, typ)
+		buf = le.AppendUint32(buf, uint32(12+len(body)))
+		buf = append(buf, body...)
+		buf = le.AppendUint32(buf, uint32(12+len(body)))
+	}
+
+	block(0x0a0d0d0a, []byte{0x4d, 0x3c, 0x2b, 0x1a, 1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	block(1, []byte{1, 0, 0, 0, 0, 0, 0, 0, 9, 0, 1, 0, 3, 0, 0, 0, 0, 0, 0, 0}) // milliseconds
+
+	for i, pkt := range c.pkts {
+		ts := uint64(c.secs[i]) * 1000
+		var body []byte
+		body = le.AppendUint32(body, 0)
+		body = le.AppendUint32(body, uint32(ts>>32))
+		body = le.AppendUint32(body, uint32(ts))
+		body = le.AppendUint32(body, uint32(len(pkt)))
+		body = le.AppendUint32(body, uint32(len(pkt)))
+		block(6, append(body, pkt...))
+	}
+	return buf
+}
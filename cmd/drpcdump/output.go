@@ -0,0 +1,258 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcwire"
+)
+
+// record is the annotated form of an event that is printed.
+type record struct {
+	Time     string            `json:"time,omitempty"`
+	Src      string            `json:"src"`
+	Dst      string            `json:"dst,omitempty"`
+	Type     string            `json:"type"`
+	Stream   uint64            `json:"stream,omitempty"`
+	Message  uint64            `json:"message,omitempty"`
+	Kind     string            `json:"kind,omitempty"`
+	Control  bool              `json:"control,omitempty"`
+	Done     *bool             `json:"done,omitempty"`
+	Size     int               `json:"size"`
+	RPC      string            `json:"rpc,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Error    *errorRecord      `json:"error,omitempty"`
+	Decoded  json.RawMessage   `json:"decoded,omitempty"`
+	Data     []byte            `json:"data,omitempty"`
+	Note     string            `json:"note,omitempty"`
+}
+
+// errorRecord is the code and message of a KindError packet.
+type errorRecord struct {
+	Code    uint64 `json:"code"`
+	Message string `json:"message"`
+}
+
+// streamInfo is what is known about a stream of a connection.
+type streamInfo struct {
+	rpc    string
+	client *flow
+}
+
+// annotator turns events into records, keeping track of the rpcs of the
+// streams of every connection so that messages can be decoded.
+type annotator struct {
+	files   *protoregistry.Files
+	data    bool
+	streams map[string]map[uint64]*streamInfo
+}
+
+// stream returns the info for the stream of the connection of the flow.
+func (a *annotator) stream(f *flow, id uint64) *streamInfo {
+	if a.streams == nil {
+		a.streams = make(map[string]map[uint64]*streamInfo)
+	}
+	conn := a.streams[f.conn]
+	if conn == nil {
+		conn = make(map[uint64]*streamInfo)
+		a.streams[f.conn] = conn
+	}
+	info := conn[id]
+	if info == nil {
+		info = new(streamInfo)
+		conn[id] = info
+	}
+	return info
+}
+
+// annotate returns the record for the event.
+func (a *annotator) annotate(ev event) record {
+	rec := record{Src: ev.flow.src, Dst: ev.flow.dst, Type: ev.typ, Note: ev.note}
+	if !ev.time.IsZero() {
+		rec.Time = ev.time.Format(time.RFC3339Nano)
+	}
+
+	switch ev.typ {
+	case "frame":
+		done := ev.fr.Done
+		rec.Stream, rec.Message = ev.fr.ID.Stream, ev.fr.ID.Message
+		rec.Kind, rec.Control, rec.Done = ev.fr.Kind.String(), ev.fr.Control, &done
+		rec.Size = len(ev.fr.Data)
+		if a.data {
+			rec.Data = ev.fr.Data
+		}
+
+	case "packet":
+		pkt := ev.pkt
+		rec.Stream, rec.Message = pkt.ID.Stream, pkt.ID.Message
+		rec.Kind, rec.Control, rec.Size = pkt.Kind.String(), pkt.Control, len(pkt.Data)
+
+		info := a.stream(ev.flow, pkt.ID.Stream)
+		switch pkt.Kind {
+		case drpcwire.KindInvoke:
+			info.rpc, info.client = string(pkt.Data), ev.flow
+
+		case drpcwire.KindInvokeMetadata:
+			md, err := drpcmetadata.Decode(pkt.Data)
+			if err != nil {
+				rec.Note = "invalid metadata: " + err.Error()
+			}
+			rec.Metadata = md
+
+		case drpcwire.KindError:
+			err := drpcwire.UnmarshalError(pkt.Data)
+			rec.Error = &errorRecord{Code: drpcerr.Code(err), Message: err.Error()}
+
+		case drpcwire.KindMessage:
+			var err error
+			rec.Decoded, err = a.decode(info, ev.flow, pkt.Data)
+			if err != nil {
+				rec.Note = "unable to decode message: " + err.Error()
+			}
+			if a.data && rec.Decoded == nil {
+				rec.Data = pkt.Data
+			}
+		}
+		rec.RPC = info.rpc
+	}
+
+	return rec
+}
+
+// decode returns the message as JSON if descriptors for the rpc of the stream
+// were provided. It returns nil if there are none.
+func (a *annotator) decode(info *streamInfo, f *flow, data []byte) (json.RawMessage, error) {
+	if a.files == nil || info.rpc == "" {
+		return nil, nil
+	}
+
+	service, method, _ := strings.Cut(strings.TrimPrefix(info.rpc, "/"), "/")
+	desc, err := a.files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, nil
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, nil
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, nil
+	}
+
+	typ := md.Output()
+	if f == info.client {
+		typ = md.Input()
+	}
+
+	msg := dynamicpb.NewMessage(typ)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	buf, err := protojson.Marshal(msg)
+	return buf, errs.Wrap(err)
+}
+
+// writeText writes the record as a line of text.
+func writeText(w io.Writer, rec record) error {
+	var b strings.Builder
+	if rec.Time != "" {
+		b.WriteString(rec.Time + " ")
+	}
+	b.WriteString(rec.Src)
+	if rec.Dst != "" {
+		b.WriteString(" -> " + rec.Dst)
+	}
+
+	switch rec.Type {
+	case "frame", "packet":
+		fmt.Fprintf(&b, " %s s:%d m:%d %s", rec.Type, rec.Stream, rec.Message, rec.Kind)
+		if rec.Control {
+			b.WriteString(" control")
+		}
+		if rec.Done != nil {
+			fmt.Fprintf(&b, " done:%v", *rec.Done)
+		}
+		fmt.Fprintf(&b, " size:%d", rec.Size)
+		if rec.RPC != "" {
+			fmt.Fprintf(&b, " rpc:%q", rec.RPC)
+		}
+		if rec.Metadata != nil {
+			fmt.Fprintf(&b, " metadata:%v", rec.Metadata)
+		}
+		if rec.Error != nil {
+			fmt.Fprintf(&b, " code:%d error:%q", rec.Error.Code, rec.Error.Message)
+		}
+		if rec.Decoded != nil {
+			fmt.Fprintf(&b, " decoded:%s", rec.Decoded)
+		}
+		if rec.Data != nil {
+			fmt.Fprintf(&b, " data:%s", hex.EncodeToString(rec.Data))
+		}
+		if rec.Note != "" {
+			fmt.Fprintf(&b, " (%s)", rec.Note)
+		}
+
+	default:
+		fmt.Fprintf(&b, " note: %s", rec.Note)
+	}
+
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return errs.Wrap(err)
+}
+
+// writeJSON writes the record as a line of JSON.
+func writeJSON(w io.Writer, rec record) error {
+	buf, err := json.Marshal(rec)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	_, err = w.Write(append(buf, '\n'))
+	return errs.Wrap(err)
+}
+
+// loadProtosets reads the binary FileDescriptorSets from the files.
+func loadProtosets(paths []string) (*protoregistry.Files, error) {
+	var set descriptorpb.FileDescriptorSet
+	seen := make(map[string]bool)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		var part descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(data, &part); err != nil {
+			return nil, errs.New("invalid protoset %q: %v", path, err)
+		}
+		for _, fd := range part.File {
+			if !seen[fd.GetName()] {
+				seen[fd.GetName()] = true
+				set.File = append(set.File, fd)
+			}
+		}
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	return files, errs.Wrap(err)
+}
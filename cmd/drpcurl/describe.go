@@ -0,0 +1,129 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// list writes the names of the services of the schema.
+func list(ctx context.Context, out io.Writer, sch schema) error {
+	services, err := sch.services(ctx)
+	if err != nil {
+		return err
+	}
+	return writeLines(out, services)
+}
+
+// listMethods writes the names of the methods of the service.
+func listMethods(ctx context.Context, out io.Writer, sch schema, service string) error {
+	methods, err := sch.methods(ctx, service)
+	if err != nil {
+		return err
+	}
+	return writeLines(out, methods)
+}
+
+// describe writes the definition of the symbol in protobuf syntax.
+func describe(ctx context.Context, out io.Writer, sch schema, symbol string) error {
+	desc, err := sch.find(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s is defined in %s\n", desc.FullName(), desc.ParentFile().Path())
+
+	switch desc := desc.(type) {
+	case protoreflect.ServiceDescriptor:
+		fmt.Fprintf(&b, "service %s {\n", desc.Name())
+		for i := 0; i < desc.Methods().Len(); i++ {
+			fmt.Fprintf(&b, "  %s\n", methodString(desc.Methods().Get(i)))
+		}
+		b.WriteString("}\n")
+
+	case protoreflect.MethodDescriptor:
+		fmt.Fprintf(&b, "%s\n", methodString(desc))
+
+	case protoreflect.MessageDescriptor:
+		fmt.Fprintf(&b, "message %s {\n", desc.Name())
+		for i := 0; i < desc.Fields().Len(); i++ {
+			fmt.Fprintf(&b, "  %s\n", fieldString(desc.Fields().Get(i)))
+		}
+		b.WriteString("}\n")
+
+	case protoreflect.EnumDescriptor:
+		fmt.Fprintf(&b, "enum %s {\n", desc.Name())
+		for i := 0; i < desc.Values().Len(); i++ {
+			val := desc.Values().Get(i)
+			fmt.Fprintf(&b, "  %s = %d;\n", val.Name(), val.Number())
+		}
+		b.WriteString("}\n")
+
+	case protoreflect.FieldDescriptor:
+		fmt.Fprintf(&b, "%s\n", fieldString(desc))
+
+	default:
+		return errs.New("unable to describe %q", symbol)
+	}
+
+	_, err = io.WriteString(out, b.String())
+	return errs.Wrap(err)
+}
+
+// methodString returns the definition of the method in protobuf syntax.
+func methodString(md protoreflect.MethodDescriptor) string {
+	stream := func(streaming bool) string {
+		if streaming {
+			return "stream "
+		}
+		return ""
+	}
+	return fmt.Sprintf("rpc %s(%s%s) returns (%s%s);", md.Name(),
+		stream(md.IsStreamingClient()), md.Input().FullName(),
+		stream(md.IsStreamingServer()), md.Output().FullName())
+}
+
+// fieldString returns the definition of the field in protobuf syntax.
+func fieldString(fd protoreflect.FieldDescriptor) string {
+	var label string
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s> %s = %d;",
+			typeString(fd.MapKey()), typeString(fd.MapValue()), fd.Name(), fd.Number())
+	case fd.IsList():
+		label = "repeated "
+	case fd.HasOptionalKeyword():
+		label = "optional "
+	}
+	return fmt.Sprintf("%s%s %s = %d;", label, typeString(fd), fd.Name(), fd.Number())
+}
+
+// typeString returns the name of the type of the field.
+func typeString(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fd.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+// writeLines writes every line followed by a newline.
+func writeLines(out io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcmigrate"
+)
+
+// dial connects to the address, sending the drpcmigrate header and doing a
+// TLS handshake if configured to.
+func dial(ctx context.Context, address string, conf config) (*drpcconn.Conn, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, conf.connectTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(dialCtx, "tcp", address)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	// the header is sent before the tls handshake so that servers can route
+	// the connection before terminating tls, like with drpcmigrate.ListenMux.
+	if conf.migrate {
+		conn = drpcmigrate.NewHeaderConn(conn, drpcmigrate.DRPCHeader)
+	}
+
+	if conf.tls {
+		tlsConf, err := tlsConfig(address, conf)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		tlsConn := tls.Client(conn, tlsConf)
+		if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+			_ = conn.Close()
+			return nil, errs.Wrap(err)
+		}
+		conn = tlsConn
+	}
+
+	return drpcconn.New(conn), nil
+}
+
+// tlsConfig builds the tls configuration for connecting to the address.
+func tlsConfig(address string, conf config) (*tls.Config, error) {
+	tlsConf := &tls.Config{
+		ServerName:         conf.serverName,
+		InsecureSkipVerify: conf.insecure, //nolint: gosec // requested with -insecure
+	}
+
+	if tlsConf.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		tlsConf.ServerName = host
+	}
+
+	if conf.caCert != "" {
+		pem, err := os.ReadFile(conf.caCert)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		tlsConf.RootCAs = x509.NewCertPool()
+		if !tlsConf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errs.New("no certificates found in %q", conf.caCert)
+		}
+	}
+
+	if conf.cert != "" || conf.key != "" {
+		cert, err := tls.LoadX509KeyPair(conf.cert, conf.key)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConf, nil
+}
+
+// withHeaders returns a context that sends the 'key: value' headers as
+// metadata.
+func withHeaders(ctx context.Context, headers []string) (context.Context, error) {
+	metadata := make(map[string]string, len(headers))
+	for _, header := range headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return nil, errs.New("invalid header %q: must be 'key: value'", header)
+		}
+		metadata[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return drpcmetadata.AddPairs(ctx, metadata), nil
+}
@@ -0,0 +1,15 @@
+module storj.io/drpc/cmd/drpcurl
+
+go 1.21
+
+require (
+	github.com/bufbuild/protocompile v0.14.1
+	github.com/zeebo/assert v1.3.0
+	github.com/zeebo/errs v1.2.2
+	google.golang.org/protobuf v1.34.2
+	storj.io/drpc v0.0.0-00010101000000-000000000000
+)
+
+require golang.org/x/sync v0.8.0 // indirect
+
+replace storj.io/drpc => ../../
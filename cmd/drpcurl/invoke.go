@@ -0,0 +1,150 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"storj.io/drpc"
+)
+
+// encoding marshals protobuf messages, including dynamic ones.
+type encoding struct{}
+
+func (encoding) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (encoding) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+// invoke calls the method with the JSON messages read from in and writes the
+// JSON responses to out.
+func invoke(ctx context.Context, conn drpc.Conn, sch schema, method string, in io.Reader, out io.Writer) error {
+	md, err := findMethod(ctx, sch, method)
+	if err != nil {
+		return err
+	}
+	rpc := "/" + string(md.Parent().FullName()) + "/" + string(md.Name())
+
+	reqs, err := readMessages(in, md.Input())
+	if err != nil {
+		return err
+	}
+	if !md.IsStreamingClient() {
+		switch len(reqs) {
+		case 0:
+			reqs = append(reqs, dynamicpb.NewMessage(md.Input()))
+		case 1:
+		default:
+			return errs.New("%s accepts a single request message but %d were given", md.FullName(), len(reqs))
+		}
+	}
+
+	if !md.IsStreamingClient() && !md.IsStreamingServer() {
+		resp := dynamicpb.NewMessage(md.Output())
+		if err := conn.Invoke(ctx, rpc, encoding{}, reqs[0], resp); err != nil {
+			return err
+		}
+		return writeMessage(out, resp)
+	}
+
+	stream, err := conn.NewStream(ctx, rpc, encoding{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	// send concurrently with receiving so that bidirectional streams that
+	// respond to every message do not block.
+	sent := make(chan error, 1)
+	go func() {
+		for _, req := range reqs {
+			if err := stream.MsgSend(req, encoding{}); err != nil {
+				sent <- err
+				return
+			}
+		}
+		sent <- stream.CloseSend()
+	}()
+
+	for {
+		resp := dynamicpb.NewMessage(md.Output())
+		if err := stream.MsgRecv(resp, encoding{}); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := writeMessage(out, resp); err != nil {
+			return err
+		}
+	}
+
+	return <-sent
+}
+
+// findMethod returns the descriptor of a method named like
+// package.Service/Method or package.Service.Method.
+func findMethod(ctx context.Context, sch schema, method string) (protoreflect.MethodDescriptor, error) {
+	service, name := splitRPC(method)
+	if name == "" {
+		if i := strings.LastIndexByte(service, '.'); i >= 0 {
+			service, name = service[:i], service[i+1:]
+		}
+	}
+
+	desc, err := sch.find(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errs.New("%q is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(name))
+	if md == nil {
+		return nil, errs.New("service %q has no method %q", service, name)
+	}
+	return md, nil
+}
+
+// readMessages decodes a sequence of JSON messages of the type from in.
+func readMessages(in io.Reader, typ protoreflect.MessageDescriptor) (msgs []proto.Message, err error) {
+	dec := json.NewDecoder(in)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			return msgs, nil
+		} else if err != nil {
+			return nil, errs.New("invalid request JSON: %w", err)
+		}
+
+		msg := dynamicpb.NewMessage(typ)
+		if err := protojson.Unmarshal(raw, msg); err != nil {
+			return nil, errs.New("invalid %s: %w", typ.FullName(), err)
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// writeMessage writes the message as indented JSON.
+func writeMessage(out io.Writer, msg proto.Message) error {
+	buf, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	_, err = out.Write(append(buf, '\n'))
+	return errs.Wrap(err)
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// drpcurl is a command line client for drpc servers.
+//
+// It invokes unary and streaming rpcs with messages written as JSON, and can
+// list the services and describe the symbols of a server. The schemas are
+// loaded from .proto files, from descriptor sets produced by
+// protoc --descriptor_set_out, or from the drpcreflection service if neither
+// is given.
+//
+// Usage:
+//
+//	drpcurl [flags] address list [service]
+//	drpcurl [flags] address describe symbol
+//	drpcurl [flags] address package.Service/Method
+//
+// Request messages are given with -d, or read from stdin with -d @, as a
+// sequence of JSON objects. Unary and server streaming rpcs send a single
+// message, which is empty if none is given. Response messages are written to
+// stdout as JSON.
+//
+// Examples:
+//
+//	drpcurl localhost:8080 list
+//	drpcurl -proto service.proto localhost:8080 describe pkg.Service
+//	drpcurl -d '{"service": ""}' localhost:8080 drpc.health.v1.Health/Check
+//	drpcurl -tls -migrate -H 'authorization: token' -d @ localhost:8080 pkg.Service/Upload < msgs.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+type config struct {
+	protos      stringsFlag
+	importPaths stringsFlag
+	protosets   stringsFlag
+	headers     stringsFlag
+	data        string
+
+	migrate        bool
+	tls            bool
+	insecure       bool
+	caCert         string
+	cert           string
+	key            string
+	serverName     string
+	connectTimeout time.Duration
+	timeout        time.Duration
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "drpcurl:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs drpcurl with the command line arguments.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var conf config
+
+	flags := flag.NewFlagSet("drpcurl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&conf.protos, "proto", "a .proto file to load schemas from (repeatable)")
+	flags.Var(&conf.importPaths, "import-path", "a directory to search for .proto files and their imports (repeatable)")
+	flags.Var(&conf.protosets, "protoset", "a binary FileDescriptorSet file to load schemas from (repeatable)")
+	flags.Var(&conf.headers, "H", "metadata to send with the rpc as 'key: value' (repeatable)")
+	flags.StringVar(&conf.data, "d", "", "request messages as JSON, or @ to read them from stdin")
+	flags.BoolVar(&conf.migrate, "migrate", false, "send the drpcmigrate header when connecting")
+	flags.BoolVar(&conf.tls, "tls", false, "connect using TLS")
+	flags.BoolVar(&conf.insecure, "insecure", false, "skip verification of the server certificate")
+	flags.StringVar(&conf.caCert, "cacert", "", "a file of PEM encoded certificates to verify the server with")
+	flags.StringVar(&conf.cert, "cert", "", "a file with a PEM encoded client certificate")
+	flags.StringVar(&conf.key, "key", "", "a file with the PEM encoded private key of the client certificate")
+	flags.StringVar(&conf.serverName, "servername", "", "the server name to verify the certificate against")
+	flags.DurationVar(&conf.connectTimeout, "connect-timeout", 10*time.Second, "how long to wait to connect")
+	flags.DurationVar(&conf.timeout, "timeout", 0, "how long to wait for the command to finish, or zero for no limit")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage:")
+		fmt.Fprintln(stderr, "  drpcurl [flags] address list [service]")
+		fmt.Fprintln(stderr, "  drpcurl [flags] address describe symbol")
+		fmt.Fprintln(stderr, "  drpcurl [flags] address package.Service/Method")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "flags:")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) < 2 {
+		flags.Usage()
+		return errs.New("an address and a command are required")
+	}
+	address, command, args := args[0], args[1], args[2:]
+
+	if conf.timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, conf.timeout)
+		defer cancel()
+	}
+
+	conn, err := dial(ctx, address, conf)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	sch, err := loadSchema(ctx, conn, conf)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case command == "list" && len(args) == 0:
+		return list(ctx, stdout, sch)
+	case command == "list" && len(args) == 1:
+		return listMethods(ctx, stdout, sch, args[0])
+	case command == "describe" && len(args) == 1:
+		return describe(ctx, stdout, sch, args[0])
+	case command == "list" || command == "describe" || len(args) > 0:
+		flags.Usage()
+		return errs.New("invalid arguments for %q", command)
+	}
+
+	ctx, err = withHeaders(ctx, conf.headers)
+	if err != nil {
+		return err
+	}
+
+	in := io.Reader(strings.NewReader(conf.data))
+	if conf.data == "@" {
+		in = stdin
+	}
+	return invoke(ctx, conn, sch, command, in, stdout)
+}
+
+// stringsFlag is a flag that can be given multiple times.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string     { return strings.Join(*s, ",") }
+func (s *stringsFlag) Set(v string) error { *s = append(*s, v); return nil }
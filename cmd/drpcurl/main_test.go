@@ -0,0 +1,134 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc/drpchealth"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/drpcreflection"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+)
+
+func TestList(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	addr := serve(t, ctx)
+
+	assert.Equal(t, drpcurl(t, ctx, addr, "list"), ""+
+		"drpc.health.v1.Health\n"+
+		"drpc.reflection.v1.Reflection\n")
+
+	assert.Equal(t, drpcurl(t, ctx, addr, "list", "drpc.health.v1.Health"), ""+
+		"drpc.health.v1.Health.Check\n"+
+		"drpc.health.v1.Health.Watch\n")
+}
+
+func TestDescribe(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	addr := serve(t, ctx)
+
+	// write a descriptor set for the health service.
+	set, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(drpchealth.File_storj_io_drpc_drpchealth_health_proto)},
+	})
+	assert.NoError(t, err)
+	protoset := filepath.Join(t.TempDir(), "health.protoset")
+	assert.NoError(t, os.WriteFile(protoset, set, 0o644))
+
+	// lay out the proto file by its import path, as it is registered.
+	src, err := os.ReadFile("../../drpchealth/health.proto")
+	assert.NoError(t, err)
+	imports := t.TempDir()
+	dir := filepath.Join(imports, "storj.io", "drpc", "drpchealth")
+	assert.NoError(t, os.MkdirAll(dir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "health.proto"), src, 0o644))
+
+	// every schema source describes the service the same way.
+	for _, flags := range [][]string{
+		nil,
+		{"-proto", "storj.io/drpc/drpchealth/health.proto", "-import-path", imports},
+		{"-protoset", protoset},
+	} {
+		args := append([]string{}, flags...)
+		args = append(args, addr, "describe", "drpc.health.v1.Health")
+		assert.Equal(t, drpcurl(t, ctx, args...), ""+
+			"// drpc.health.v1.Health is defined in storj.io/drpc/drpchealth/health.proto\n"+
+			"service Health {\n"+
+			"  rpc Check(drpc.health.v1.HealthCheckRequest) returns (drpc.health.v1.HealthCheckResponse);\n"+
+			"  rpc Watch(drpc.health.v1.HealthCheckRequest) returns (stream drpc.health.v1.HealthCheckResponse);\n"+
+			"}\n")
+	}
+
+	assert.Equal(t, drpcurl(t, ctx, addr, "describe", "drpc.health.v1.HealthCheckResponse"), ""+
+		"// drpc.health.v1.HealthCheckResponse is defined in storj.io/drpc/drpchealth/health.proto\n"+
+		"message HealthCheckResponse {\n"+
+		"  drpc.health.v1.HealthCheckResponse.ServingStatus status = 1;\n"+
+		"}\n")
+}
+
+func TestInvoke(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	addr := serve(t, ctx)
+
+	out := drpcurl(t, ctx, "-H", "key: value", "-d", `{"service": ""}`, addr, "drpc.health.v1.Health/Check")
+	assert.Equal(t, fields(out), `{ "status": "SERVING" }`)
+
+	// streaming responses are written until the stream ends.
+	var stdout bytes.Buffer
+	err := run(ctx, []string{"-timeout", "100ms", "-d", "@", addr, "drpc.health.v1.Health.Watch"},
+		strings.NewReader(`{"service": "unknown"}`), &stdout, new(bytes.Buffer))
+	assert.Error(t, err)
+	assert.Equal(t, fields(stdout.String()), `{ "status": "SERVICE_UNKNOWN" }`)
+
+	// unknown services fail.
+	err = run(ctx, []string{"-d", `{"service": "unknown"}`, addr, "drpc.health.v1.Health/Check"},
+		nil, new(bytes.Buffer), new(bytes.Buffer))
+	assert.Error(t, err)
+}
+
+// fields normalizes the whitespace of the output, which protojson randomly
+// varies so that it is not compared byte for byte.
+func fields(out string) string {
+	return strings.Join(strings.Fields(out), " ")
+}
+
+func drpcurl(t *testing.T, ctx context.Context, args ...string) string {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(ctx, args, nil, &stdout, &stderr)
+	assert.NoError(t, err)
+	return stdout.String()
+}
+
+func serve(t *testing.T, ctx *drpctest.Tracker) string {
+	mux := drpcmux.New()
+	assert.NoError(t, drpchealth.DRPCRegisterHealth(mux, drpchealth.NewServer()))
+	assert.NoError(t, drpcreflection.Register(mux))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	ctx.Run(func(ctx context.Context) { _ = drpcserver.New(mux).Serve(ctx, lis) })
+	return lis.Addr().String()
+}
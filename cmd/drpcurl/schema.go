@@ -0,0 +1,218 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcreflection"
+)
+
+// schema is a source of the services and descriptors of a server.
+type schema interface {
+	// services returns the full names of the services sorted by name.
+	services(ctx context.Context) ([]string, error)
+
+	// methods returns the full names of the methods of the service sorted
+	// by name.
+	methods(ctx context.Context, service string) ([]string, error)
+
+	// find returns the descriptor with the full name.
+	find(ctx context.Context, name string) (protoreflect.Descriptor, error)
+}
+
+// loadSchema returns a schema from the configured .proto and descriptor set
+// files, or from the reflection service on the connection if there are none.
+func loadSchema(ctx context.Context, conn drpc.Conn, conf config) (schema, error) {
+	if len(conf.protos) == 0 && len(conf.protosets) == 0 {
+		return &reflectionSchema{conn: conn}, nil
+	}
+
+	files := new(protoregistry.Files)
+
+	if len(conf.protos) > 0 {
+		compiler := protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+				ImportPaths: conf.importPaths,
+			}),
+		}
+		compiled, err := compiler.Compile(ctx, conf.protos...)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		for _, fd := range compiled {
+			if err := register(files, fd); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, path := range conf.protosets {
+		set, err := readProtoset(path)
+		if err != nil {
+			return nil, err
+		}
+		var rerr error
+		set.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+			rerr = register(files, fd)
+			return rerr == nil
+		})
+		if rerr != nil {
+			return nil, rerr
+		}
+	}
+
+	return &filesSchema{files: files}, nil
+}
+
+// readProtoset reads a binary FileDescriptorSet from the file at path.
+func readProtoset(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, errs.New("invalid protoset %q: %v", path, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	return files, errs.Wrap(err)
+}
+
+// register adds the file and every file it transitively imports to the files
+// if they are not already present.
+func register(files *protoregistry.Files, fd protoreflect.FileDescriptor) error {
+	if _, err := files.FindFileByPath(fd.Path()); err == nil {
+		return nil
+	}
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if err := register(files, imports.Get(i).FileDescriptor); err != nil {
+			return err
+		}
+	}
+	return errs.Wrap(files.RegisterFile(fd))
+}
+
+// filesSchema is a schema backed by a set of loaded files.
+type filesSchema struct {
+	files *protoregistry.Files
+}
+
+func (s *filesSchema) services(ctx context.Context) (out []string, err error) {
+	s.files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			out = append(out, string(fd.Services().Get(i).FullName()))
+		}
+		return true
+	})
+	sort.Strings(out)
+	return out, nil
+}
+
+func (s *filesSchema) methods(ctx context.Context, service string) (out []string, err error) {
+	desc, err := s.find(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errs.New("%q is not a service", service)
+	}
+	for i := 0; i < sd.Methods().Len(); i++ {
+		out = append(out, string(sd.Methods().Get(i).FullName()))
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func (s *filesSchema) find(ctx context.Context, name string) (protoreflect.Descriptor, error) {
+	desc, err := s.files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, errs.New("symbol %q not found", name)
+	}
+	return desc, nil
+}
+
+// reflectionSchema is a schema backed by the drpcreflection service.
+type reflectionSchema struct {
+	conn drpc.Conn
+}
+
+// rpcs returns the names of every rpc on the server.
+func (s *reflectionSchema) rpcs(ctx context.Context) ([]string, error) {
+	resp, err := drpcreflection.NewDRPCReflectionClient(s.conn).ListRPCs(ctx, &drpcreflection.ListRPCsRequest{})
+	if err != nil {
+		return nil, errs.New("unable to use reflection service: %w", err)
+	}
+	var out []string
+	for _, rpc := range resp.GetRpcs() {
+		out = append(out, rpc.GetName())
+	}
+	return out, nil
+}
+
+func (s *reflectionSchema) services(ctx context.Context) (out []string, err error) {
+	rpcs, err := s.rpcs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, rpc := range rpcs {
+		service, _ := splitRPC(rpc)
+		if !seen[service] {
+			seen[service] = true
+			out = append(out, service)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func (s *reflectionSchema) methods(ctx context.Context, service string) (out []string, err error) {
+	rpcs, err := s.rpcs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rpc := range rpcs {
+		if svc, method := splitRPC(rpc); svc == service {
+			out = append(out, svc+"."+method)
+		}
+	}
+	if len(out) == 0 {
+		return nil, errs.New("service %q not found", service)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func (s *reflectionSchema) find(ctx context.Context, name string) (protoreflect.Descriptor, error) {
+	files, err := drpcreflection.Files(ctx, s.conn, name)
+	if err != nil {
+		return nil, errs.New("symbol %q not found: %w", name, err)
+	}
+	return files.FindDescriptorByName(protoreflect.FullName(name))
+}
+
+// splitRPC splits an rpc name like /package.Service/Method into the service
+// and method names.
+func splitRPC(rpc string) (service, method string) {
+	rpc = strings.TrimPrefix(rpc, "/")
+	if i := strings.LastIndexByte(rpc, '/'); i >= 0 {
+		return rpc[:i], rpc[i+1:]
+	}
+	return rpc, ""
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc/drpchttp"
+)
+
+// httpRuleField is the field number of the google.api.http extension of
+// google.protobuf.MethodOptions. The annotations are read from the raw option
+// bytes so that the plugin does not depend on the googleapis protos.
+const httpRuleField = 72295728
+
+// field numbers of the google.api.HttpRule and google.api.CustomHttpPattern
+// messages.
+const (
+	httpRuleGet                = 2
+	httpRulePut                = 3
+	httpRulePost               = 4
+	httpRuleDelete             = 5
+	httpRulePatch              = 6
+	httpRuleBody               = 7
+	httpRuleCustom             = 8
+	httpRuleAdditionalBindings = 11
+	httpRuleResponseBody       = 12
+
+	customPatternKind = 1
+	customPatternPath = 2
+)
+
+// httpRoutes returns the routes from the google.api.http annotation of the
+// method, including its additional bindings. Streaming methods have no routes
+// because drpchttp only serves unary rpcs.
+func httpRoutes(method *protogen.Method) ([]drpchttp.Route, error) {
+	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+		return nil, nil
+	}
+
+	opts, ok := method.Desc.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return nil, nil
+	}
+
+	var routes []drpchttp.Route
+	err := walkFields(opts.ProtoReflect().GetUnknown(), func(num protowire.Number, typ protowire.Type, b []byte) error {
+		if num != httpRuleField || typ != protowire.BytesType {
+			return nil
+		}
+		var err error
+		routes, err = parseHTTPRule(routes, b, true)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid google.api.http option: %w", method.Desc.FullName(), err)
+	}
+
+	for i := range routes {
+		routes[i].RPC = rpcName(method)
+		if err := routes[i].Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+		}
+		if err := checkField(method.Input, routes[i].Body); err != nil {
+			return nil, fmt.Errorf("%s: body: %w", method.Desc.FullName(), err)
+		}
+		if err := checkField(method.Output, routes[i].ResponseBody); err != nil {
+			return nil, fmt.Errorf("%s: response_body: %w", method.Desc.FullName(), err)
+		}
+	}
+	return routes, nil
+}
+
+// parseHTTPRule appends the routes in the encoded google.api.HttpRule to
+// routes. Additional bindings are only parsed at the top level.
+func parseHTTPRule(routes []drpchttp.Route, b []byte, top bool) ([]drpchttp.Route, error) {
+	var route drpchttp.Route
+	var additional [][]byte
+
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, b []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case httpRuleGet:
+			route.Method, route.Pattern = "GET", string(b)
+		case httpRulePut:
+			route.Method, route.Pattern = "PUT", string(b)
+		case httpRulePost:
+			route.Method, route.Pattern = "POST", string(b)
+		case httpRuleDelete:
+			route.Method, route.Pattern = "DELETE", string(b)
+		case httpRulePatch:
+			route.Method, route.Pattern = "PATCH", string(b)
+		case httpRuleCustom:
+			route.Method, route.Pattern = "", ""
+			return walkFields(b, func(num protowire.Number, typ protowire.Type, b []byte) error {
+				switch {
+				case typ != protowire.BytesType:
+				case num == customPatternKind:
+					route.Method = string(b)
+				case num == customPatternPath:
+					route.Pattern = string(b)
+				}
+				return nil
+			})
+		case httpRuleBody:
+			route.Body = string(b)
+		case httpRuleResponseBody:
+			route.ResponseBody = string(b)
+		case httpRuleAdditionalBindings:
+			if top {
+				additional = append(additional, b)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if route.Method != "" || route.Pattern != "" {
+		routes = append(routes, route)
+	}
+	for _, b := range additional {
+		if routes, err = parseHTTPRule(routes, b, false); err != nil {
+			return nil, err
+		}
+	}
+	return routes, nil
+}
+
+// walkFields calls cb with every field in the encoded message. The bytes
+// passed for length delimited fields are their contents.
+func walkFields(b []byte, cb func(num protowire.Number, typ protowire.Type, b []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var value []byte
+		if typ == protowire.BytesType {
+			value, n = protowire.ConsumeBytes(b)
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := cb(num, typ, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkField returns an error if name is not empty, "*", or a field of the
+// message.
+func checkField(msg *protogen.Message, name string) error {
+	if name == "" || name == "*" {
+		return nil
+	}
+	for _, field := range msg.Fields {
+		if string(field.Desc.Name()) == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s has no field %q", msg.Desc.FullName(), name)
+}
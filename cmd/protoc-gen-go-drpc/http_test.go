@@ -0,0 +1,204 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"storj.io/drpc/drpchttp"
+)
+
+func TestParseHTTPRule(t *testing.T) {
+	str := func(b []byte, num protowire.Number, s string) []byte {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendString(b, s)
+	}
+	msg := func(b []byte, num protowire.Number, m []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, m)
+	}
+
+	var custom []byte
+	custom = str(custom, customPatternKind, "HEAD")
+	custom = str(custom, customPatternPath, "/v1/{name=books/*}")
+
+	var nested []byte
+	nested = str(nested, httpRuleGet, "/v1/nested")
+
+	var additional []byte
+	additional = msg(additional, httpRuleCustom, custom)
+	additional = msg(additional, httpRuleAdditionalBindings, nested) // ignored
+
+	var rule []byte
+	rule = protowire.AppendTag(rule, 1, protowire.VarintType) // unknown
+	rule = protowire.AppendVarint(rule, 5)
+	rule = str(rule, httpRulePost, "/v1/{parent=shelves/*}/books")
+	rule = str(rule, httpRuleBody, "book")
+	rule = str(rule, httpRuleResponseBody, "name")
+	rule = msg(rule, httpRuleAdditionalBindings, additional)
+
+	routes, err := parseHTTPRule(nil, rule, true)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, routes, []drpchttp.Route{
+		{Method: "POST", Pattern: "/v1/{parent=shelves/*}/books", Body: "book", ResponseBody: "name"},
+		{Method: "HEAD", Pattern: "/v1/{name=books/*}"},
+	})
+
+	_, err = parseHTTPRule(nil, rule[:len(rule)-1], true)
+	assert.Error(t, err)
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	files := runPlugin(t, config{protolib: "google.golang.org/protobuf"}, testFile())
+	assert.That(t, strings.Contains(files["lib_drpc.pb.go"], `func DRPCLibraryRoutes() []drpchttp.Route {
+	return []drpchttp.Route{
+		{Method: "GET", Pattern: "/v1/{name=shelves/*/books/*}", RPC: "/lib.Library/GetBook"},
+		{Method: "PATCH", Pattern: "/v1/books", RPC: "/lib.Library/GetBook", Body: "*", ResponseBody: "name"},
+	}
+}`))
+
+	// the option on the streaming method is not generated as a route.
+	assert.That(t, !strings.Contains(files["lib_drpc.pb.go"], `RPC: "/lib.Library/WatchBook"`))
+
+	file := testFile()
+	file.Service[0].Method[0].Options.ProtoReflect().SetUnknown(httpOption(httpRuleGet, "/v1/{name"))
+	_, err := runPluginErr(config{protolib: "google.golang.org/protobuf"}, file)
+	assert.Error(t, err)
+}
+
+//
+// helpers
+//
+
+// testFile returns a file with a Library service that has a GetBook method
+// annotated with google.api.http options, and a WatchBook streaming method
+// annotated with one that is not served.
+func testFile() *descriptorpb.FileDescriptorProto {
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(num),
+			Type:   typ.Enum(),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+		if typeName != "" {
+			fd.TypeName = proto.String(typeName)
+		}
+		return fd
+	}
+
+	var additional []byte
+	additional = protowire.AppendTag(additional, httpRulePatch, protowire.BytesType)
+	additional = protowire.AppendString(additional, "/v1/books")
+	additional = protowire.AppendTag(additional, httpRuleBody, protowire.BytesType)
+	additional = protowire.AppendString(additional, "*")
+	additional = protowire.AppendTag(additional, httpRuleResponseBody, protowire.BytesType)
+	additional = protowire.AppendString(additional, "name")
+
+	var rule []byte
+	rule = protowire.AppendTag(rule, httpRuleGet, protowire.BytesType)
+	rule = protowire.AppendString(rule, "/v1/{name=shelves/*/books/*}")
+	rule = protowire.AppendTag(rule, httpRuleAdditionalBindings, protowire.BytesType)
+	rule = protowire.AppendBytes(rule, additional)
+
+	opts := new(descriptorpb.MethodOptions)
+	opts.ProtoReflect().SetUnknown(protowire.AppendBytes(
+		protowire.AppendTag(nil, httpRuleField, protowire.BytesType), rule))
+
+	watchOpts := new(descriptorpb.MethodOptions)
+	watchOpts.ProtoReflect().SetUnknown(httpOption(httpRuleGet, "/v1/{name=shelves/*/books/*}:watch"))
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("lib.proto"),
+		Package: proto.String("lib"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/lib")},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("View"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("BASIC"), Number: proto.Int32(0)},
+				{Name: proto.String("FULL"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("GetBookRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("page_size", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				field("view", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".lib.View"),
+				field("filter", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".lib.Book"),
+			},
+		}, {
+			Name: proto.String("Book"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Library"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("GetBook"),
+				InputType:  proto.String(".lib.GetBookRequest"),
+				OutputType: proto.String(".lib.Book"),
+				Options:    opts,
+			}, {
+				Name:            proto.String("WatchBook"),
+				InputType:       proto.String(".lib.GetBookRequest"),
+				OutputType:      proto.String(".lib.Book"),
+				ServerStreaming: proto.Bool(true),
+				Options:         watchOpts,
+			}},
+		}},
+	}
+}
+
+// httpOption returns the raw bytes of a google.api.http option with the
+// pattern set for the method field.
+func httpOption(method protowire.Number, pattern string) []byte {
+	var rule []byte
+	rule = protowire.AppendTag(rule, method, protowire.BytesType)
+	rule = protowire.AppendString(rule, pattern)
+	return protowire.AppendBytes(protowire.AppendTag(nil, httpRuleField, protowire.BytesType), rule)
+}
+
+// runPlugin runs the plugin with the configuration on the file and returns the
+// contents of the generated files by name.
+func runPlugin(t *testing.T, conf config, file *descriptorpb.FileDescriptorProto) map[string]string {
+	files, err := runPluginErr(conf, file)
+	assert.NoError(t, err)
+	return files
+}
+
+func runPluginErr(conf config, file *descriptorpb.FileDescriptorProto) (map[string]string, error) {
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative"),
+		FileToGenerate: []string{file.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := generate(plugin, conf); err != nil {
+		return nil, err
+	}
+
+	resp := plugin.Response()
+	if resp.Error != nil {
+		return nil, errors.New(resp.GetError())
+	}
+	files := make(map[string]string)
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files, nil
+}
@@ -2,6 +2,31 @@
 // See LICENSE for copying information.
 
 // protoc-gen-go-drpc generates DRPC code for protobuf services.
+//
+// With the mock=true parameter, it also generates a _drpc_mock.pb.go file with
+// a mock of every client interface that records calls and defers to settable
+// functions, and a mock of every client stream that returns scripted
+// responses.
+//
+// Unary methods annotated with google.api.http options have their HTTP
+// bindings emitted as a DRPC<Service>Routes function returning drpchttp.Routes,
+// which can be passed to drpchttp.WithRoutes to serve them as a REST API. The
+// options of streaming methods are ignored because drpchttp cannot serve them.
+//
+// With the openapi=true parameter, it also generates a .openapi.json file with
+// an OpenAPI 3 document describing the JSON endpoints drpchttp serves for the
+// unitary RPCs: the POST /package.Service/Method paths, and the paths of any
+// google.api.http annotations.
+//
+// Services with methods that declare options, like custom options defined as
+// extensions of google.protobuf.MethodOptions, have a Description that
+// implements drpc.DescriptionOptions, so that the options are available at
+// runtime from drpcmux.Mux.
+//
+// Every Description also implements drpc.DescriptionInfo, describing the
+// streaming kind, the message types and, for the google.golang.org/protobuf
+// library, the descriptor of each method, so that muxes and other tools do not
+// have to inspect the server interface with reflection.
 package main
 
 import (
@@ -12,12 +37,18 @@ import (
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
+
+	"storj.io/drpc/drpchttp"
 )
 
 type config struct {
 	protolib string
 	json     bool
+	mock     bool
+	openapi  bool
 }
 
 func main() {
@@ -25,37 +56,61 @@ func main() {
 	var conf config
 	flags.StringVar(&conf.protolib, "protolib", "google.golang.org/protobuf", "which protobuf library to use for encoding")
 	flags.BoolVar(&conf.json, "json", true, "generate encoders with json support")
+	flags.BoolVar(&conf.mock, "mock", false, "generate mock clients in a _drpc_mock.pb.go file")
+	flags.BoolVar(&conf.openapi, "openapi", false, "generate an OpenAPI document for the drpchttp endpoints in a .openapi.json file")
 
 	protogen.Options{
 		ParamFunc: flags.Set,
 	}.Run(func(plugin *protogen.Plugin) error {
-		for _, f := range plugin.Files {
-			if !f.Generate || len(f.Services) == 0 {
-				continue
+		return generate(plugin, conf)
+	})
+}
+
+func generate(plugin *protogen.Plugin, conf config) error {
+	for _, f := range plugin.Files {
+		if !f.Generate || len(f.Services) == 0 {
+			continue
+		}
+		if err := generateFile(plugin, f, conf); err != nil {
+			return err
+		}
+		if conf.mock {
+			generateMockFile(plugin, f)
+		}
+		if conf.openapi {
+			if err := generateOpenAPIFile(plugin, f); err != nil {
+				return err
 			}
-			generateFile(plugin, f, conf)
 		}
-		plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		return nil
-	})
+	}
+	plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	return nil
 }
 
-func generateFile(plugin *protogen.Plugin, file *protogen.File, conf config) {
+func generateFile(plugin *protogen.Plugin, file *protogen.File, conf config) error {
 	gf := plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_drpc.pb.go", file.GoImportPath)
 	d := &drpc{gf, file}
 
-	d.P("// Code generated by protoc-gen-go-drpc. DO NOT EDIT.")
-	if bi, ok := debug.ReadBuildInfo(); ok {
-		d.P("// protoc-gen-go-drpc version: ", bi.Main.Version)
+	d.generateHeader()
+	d.generateEncoding(conf)
+	for _, service := range file.Services {
+		if err := d.generateService(service, conf); err != nil {
+			return err
+		}
+		if err := d.generateRoutes(service); err != nil {
+			return err
+		}
 	}
-	d.P("// source: ", file.Desc.Path())
-	d.P()
-	d.P("package ", file.GoPackageName)
-	d.P()
+	return nil
+}
 
-	d.generateEncoding(conf)
+func generateMockFile(plugin *protogen.Plugin, file *protogen.File) {
+	gf := plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_drpc_mock.pb.go", file.GoImportPath)
+	d := &drpc{gf, file}
+
+	d.generateHeader()
 	for _, service := range file.Services {
-		d.generateService(service)
+		d.generateMock(service)
 	}
 }
 
@@ -64,6 +119,17 @@ type drpc struct {
 	file *protogen.File
 }
 
+func (d *drpc) generateHeader() {
+	d.P("// Code generated by protoc-gen-go-drpc. DO NOT EDIT.")
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		d.P("// protoc-gen-go-drpc version: ", bi.Main.Version)
+	}
+	d.P("// source: ", d.file.Desc.Path())
+	d.P()
+	d.P("package ", d.file.GoPackageName)
+	d.P()
+}
+
 //
 // name helpers
 //
@@ -77,7 +143,11 @@ func (d *drpc) EncodingName() string {
 }
 
 func (d *drpc) RPCGoString(method *protogen.Method) string {
-	return strconv.Quote(fmt.Sprintf("/%s/%s", method.Parent.Desc.FullName(), method.Desc.Name()))
+	return strconv.Quote(rpcName(method))
+}
+
+func rpcName(method *protogen.Method) string {
+	return fmt.Sprintf("/%s/%s", method.Parent.Desc.FullName(), method.Desc.Name())
 }
 
 func (d *drpc) InputType(method *protogen.Method) string {
@@ -112,6 +182,25 @@ func (d *drpc) ServerDesc(service *protogen.Service) string {
 	return "DRPC" + service.GoName + "Description"
 }
 
+func (d *drpc) RoutesFunc(service *protogen.Service) string {
+	return "DRPC" + service.GoName + "Routes"
+}
+
+func (d *drpc) MockClient(service *protogen.Service) string {
+	return "DRPC" + service.GoName + "MockClient"
+}
+
+func (d *drpc) MockCall(service *protogen.Service) string {
+	return "DRPC" + service.GoName + "MockCall"
+}
+
+func (d *drpc) MockClientStream(method *protogen.Method) string {
+	return "DRPC" +
+		strings.ReplaceAll(method.Parent.GoName, "_", "__") + "_" +
+		strings.ReplaceAll(method.GoName, "_", "__") +
+		"MockClient"
+}
+
 func (d *drpc) ClientStreamIface(method *protogen.Method) string {
 	return "DRPC" +
 		strings.ReplaceAll(method.Parent.GoName, "_", "__") + "_" +
@@ -248,7 +337,7 @@ func (d *drpc) generateEncoding(conf config) {
 // service generation
 //
 
-func (d *drpc) generateService(service *protogen.Service) {
+func (d *drpc) generateService(service *protogen.Service, conf config) error {
 	// Client interface
 	d.P("type ", d.ClientIface(service), " interface {")
 	d.P("DRPCConn() ", d.Ident("storj.io/drpc", "Conn"))
@@ -312,6 +401,10 @@ func (d *drpc) generateService(service *protogen.Service) {
 	d.P("}")
 	d.P("}")
 	d.P()
+	d.generateMethodInfo(service, conf)
+	if err := d.generateMethodOptions(service); err != nil {
+		return err
+	}
 
 	// Registration helper
 	d.P("func DRPCRegister", service.GoName, "(mux ", d.Ident("storj.io/drpc", "Mux"), ", impl ", d.ServerIface(service), ") error {")
@@ -322,6 +415,119 @@ func (d *drpc) generateService(service *protogen.Service) {
 	for _, method := range service.Methods {
 		d.generateServerMethod(method)
 	}
+	return nil
+}
+
+func (d *drpc) generateMethodInfo(service *protogen.Service, conf config) {
+	d.P("func (", d.ServerDesc(service), ") MethodInfo(n int) (", d.Ident("storj.io/drpc", "MethodInfo"), ", bool) {")
+	d.P("switch n {")
+	for i, method := range service.Methods {
+		d.P("case ", i, ":")
+		d.P("return ", d.Ident("storj.io/drpc", "MethodInfo"), "{")
+		d.P("Service: ", strconv.Quote(string(service.Desc.FullName())), ",")
+		d.P("Method: ", strconv.Quote(string(method.Desc.Name())), ",")
+		d.P("Kind: ", d.Ident("storj.io/drpc", methodKind(method)), ",")
+		d.P("NewIn: func() ", d.Ident("storj.io/drpc", "Message"), " { return new(", d.InputType(method), ") },")
+		d.P("NewOut: func() ", d.Ident("storj.io/drpc", "Message"), " { return new(", d.OutputType(method), ") },")
+		// only the google.golang.org/protobuf library has descriptors that
+		// can be referenced from the generated code.
+		if conf.protolib == "google.golang.org/protobuf" {
+			d.P("Descriptor: ", d.file.GoDescriptorIdent, ".Services().Get(", service.Desc.Index(), ").Methods().Get(", method.Desc.Index(), "),")
+		}
+		d.P("}, true")
+	}
+	d.P("default:")
+	d.P("return ", d.Ident("storj.io/drpc", "MethodInfo"), "{}, false")
+	d.P("}")
+	d.P("}")
+	d.P()
+}
+
+// methodKind returns the name of the drpc.MethodKind of the method.
+func methodKind(method *protogen.Method) string {
+	switch {
+	case method.Desc.IsStreamingClient() && method.Desc.IsStreamingServer():
+		return "MethodBidiStream"
+	case method.Desc.IsStreamingClient():
+		return "MethodClientStream"
+	case method.Desc.IsStreamingServer():
+		return "MethodServerStream"
+	default:
+		return "MethodUnary"
+	}
+}
+
+func (d *drpc) generateMethodOptions(service *protogen.Service) error {
+	options := make([][]byte, len(service.Methods))
+	found := false
+	for i, method := range service.Methods {
+		opts, ok := method.Desc.Options().(*descriptorpb.MethodOptions)
+		if !ok || opts == nil {
+			continue
+		}
+		buf, err := proto.MarshalOptions{Deterministic: true}.Marshal(opts)
+		if err != nil {
+			return fmt.Errorf("%s: marshaling options: %w", method.Desc.FullName(), err)
+		}
+		options[i], found = buf, found || len(buf) > 0
+	}
+	if !found {
+		return nil
+	}
+
+	d.P("func (", d.ServerDesc(service), ") MethodOptions(n int) []byte {")
+	d.P("switch n {")
+	for i, buf := range options {
+		if len(buf) > 0 {
+			d.P("case ", i, ":")
+			d.P("return []byte(", strconv.Quote(string(buf)), ")")
+		}
+	}
+	d.P("default:")
+	d.P("return nil")
+	d.P("}")
+	d.P("}")
+	d.P()
+	return nil
+}
+
+//
+// http route generation
+//
+
+func (d *drpc) generateRoutes(service *protogen.Service) error {
+	var routes []drpchttp.Route
+	for _, method := range service.Methods {
+		mroutes, err := httpRoutes(method)
+		if err != nil {
+			return err
+		}
+		routes = append(routes, mroutes...)
+	}
+	if len(routes) == 0 {
+		return nil
+	}
+
+	d.P()
+	d.P("func ", d.RoutesFunc(service), "() []", d.Ident("storj.io/drpc/drpchttp", "Route"), " {")
+	d.P("return []", d.Ident("storj.io/drpc/drpchttp", "Route"), "{")
+	for _, route := range routes {
+		fields := []string{
+			"Method: " + strconv.Quote(route.Method),
+			"Pattern: " + strconv.Quote(route.Pattern),
+			"RPC: " + strconv.Quote(route.RPC),
+		}
+		if route.Body != "" {
+			fields = append(fields, "Body: "+strconv.Quote(route.Body))
+		}
+		if route.ResponseBody != "" {
+			fields = append(fields, "ResponseBody: "+strconv.Quote(route.ResponseBody))
+		}
+		d.P("{", strings.Join(fields, ", "), "},")
+	}
+	d.P("}")
+	d.P("}")
+	return nil
 }
 
 //
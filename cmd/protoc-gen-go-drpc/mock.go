@@ -0,0 +1,212 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+//
+// mock generation
+//
+
+// generateMock generates a mock implementation of the client interface of the
+// service that records calls and defers to programmable functions, and a
+// scripted mock of the stream of every streaming method.
+func (d *drpc) generateMock(service *protogen.Service) {
+	mock := d.MockClient(service)
+
+	// Recorded call.
+	d.P("type ", d.MockCall(service), " struct {")
+	d.P("RPC string")
+	d.P("In ", d.Ident("storj.io/drpc", "Message"))
+	d.P("}")
+	d.P()
+
+	// Mock client.
+	d.P("type ", mock, " struct {")
+	d.P("Conn ", d.Ident("storj.io/drpc", "Conn"))
+	d.P()
+	for _, method := range service.Methods {
+		d.P(method.GoName, "Func func", d.generateClientSignature(method)[len(method.GoName):])
+	}
+	d.P()
+	d.P("mu ", d.Ident("sync", "Mutex"))
+	d.P("calls []", d.MockCall(service))
+	d.P("}")
+	d.P()
+
+	d.P("var _ ", d.ClientIface(service), " = (*", mock, ")(nil)")
+	d.P()
+
+	d.P("func (c *", mock, ") DRPCConn() ", d.Ident("storj.io/drpc", "Conn"), " { return c.Conn }")
+	d.P()
+
+	d.P("func (c *", mock, ") Calls() []", d.MockCall(service), " {")
+	d.P("c.mu.Lock()")
+	d.P("defer c.mu.Unlock()")
+	d.P("return append([]", d.MockCall(service), "(nil), c.calls...)")
+	d.P("}")
+	d.P()
+
+	d.P("func (c *", mock, ") record(rpc string, in ", d.Ident("storj.io/drpc", "Message"), ") {")
+	d.P("c.mu.Lock()")
+	d.P("defer c.mu.Unlock()")
+	d.P("c.calls = append(c.calls, ", d.MockCall(service), "{RPC: rpc, In: in})")
+	d.P("}")
+	d.P()
+
+	for _, method := range service.Methods {
+		d.generateMockMethod(method)
+	}
+
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingServer() || method.Desc.IsStreamingClient() {
+			d.generateMockStream(method)
+		}
+	}
+}
+
+func (d *drpc) generateMockMethod(method *protogen.Method) {
+	unimplemented := d.Ident("storj.io/drpc/drpcerr", "WithCode") + "(" +
+		d.Ident("errors", "New") + "(\"Unimplemented\"), " +
+		d.Ident("storj.io/drpc/drpcerr", "Unimplemented") + ")"
+
+	d.P("func (c *", d.MockClient(method.Parent), ") ", d.generateClientSignature(method), " {")
+	if method.Desc.IsStreamingClient() {
+		d.P("c.record(", d.RPCGoString(method), ", nil)")
+		d.P("if c.", method.GoName, "Func == nil { return nil, ", unimplemented, " }")
+		d.P("return c.", method.GoName, "Func(ctx)")
+	} else {
+		d.P("c.record(", d.RPCGoString(method), ", in)")
+		d.P("if c.", method.GoName, "Func == nil { return nil, ", unimplemented, " }")
+		d.P("return c.", method.GoName, "Func(ctx, in)")
+	}
+	d.P("}")
+	d.P()
+}
+
+func (d *drpc) generateMockStream(method *protogen.Method) {
+	mock := d.MockClientStream(method)
+	inType := d.InputType(method)
+	outType := d.OutputType(method)
+
+	genSend := method.Desc.IsStreamingClient()
+	genRecv := method.Desc.IsStreamingServer()
+	genCloseAndRecv := !method.Desc.IsStreamingServer()
+
+	d.P("type ", mock, " struct {")
+	d.P("Ctx ", d.Ident("context", "Context"))
+	d.P("Responses []*", outType)
+	d.P("Err error")
+	d.P("SendErr error")
+	d.P()
+	d.P("mu ", d.Ident("sync", "Mutex"))
+	d.P("sent []*", inType)
+	d.P("sendClosed bool")
+	d.P("closed bool")
+	d.P("}")
+	d.P()
+
+	d.P("var _ ", d.ClientStreamIface(method), " = (*", mock, ")(nil)")
+	d.P()
+
+	d.P("func (x *", mock, ") Context() ", d.Ident("context", "Context"), " {")
+	d.P("if x.Ctx == nil { return ", d.Ident("context", "Background"), "() }")
+	d.P("return x.Ctx")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") Sent() []*", inType, " {")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("return append([]*", inType, "(nil), x.sent...)")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") SendClosed() bool {")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("return x.sendClosed")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") Closed() bool {")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("return x.closed")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") MsgSend(msg ", d.Ident("storj.io/drpc", "Message"), ", enc ", d.Ident("storj.io/drpc", "Encoding"), ") error {")
+	d.P("m, ok := msg.(*", inType, ")")
+	d.P("if !ok { return ", d.Ident("fmt", "Errorf"), "(\"mock stream: unexpected message type %T\", msg) }")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("if x.SendErr != nil { return x.SendErr }")
+	d.P("if x.sendClosed || x.closed { return ", d.Ident("io", "EOF"), " }")
+	d.P("x.sent = append(x.sent, m)")
+	d.P("return nil")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") MsgRecv(msg ", d.Ident("storj.io/drpc", "Message"), ", enc ", d.Ident("storj.io/drpc", "Encoding"), ") error {")
+	d.P("m, err := x.recv()")
+	d.P("if err != nil { return err }")
+	d.P("buf, err := enc.Marshal(m)")
+	d.P("if err != nil { return err }")
+	d.P("return enc.Unmarshal(buf, msg)")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") recv() (*", outType, ", error) {")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("if x.closed { return nil, ", d.Ident("io", "EOF"), " }")
+	d.P("if len(x.Responses) > 0 {")
+	d.P("m := x.Responses[0]")
+	d.P("x.Responses = x.Responses[1:]")
+	d.P("return m, nil")
+	d.P("}")
+	d.P("if x.Err != nil { return nil, x.Err }")
+	d.P("return nil, ", d.Ident("io", "EOF"))
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") CloseSend() error {")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("x.sendClosed = true")
+	d.P("return nil")
+	d.P("}")
+	d.P()
+
+	d.P("func (x *", mock, ") Close() error {")
+	d.P("x.mu.Lock()")
+	d.P("defer x.mu.Unlock()")
+	d.P("x.closed = true")
+	d.P("return nil")
+	d.P("}")
+	d.P()
+
+	if genSend {
+		d.P("func (x *", mock, ") Send(m *", inType, ") error {")
+		d.P("return x.MsgSend(m, nil)")
+		d.P("}")
+		d.P()
+	}
+	if genRecv {
+		d.P("func (x *", mock, ") Recv() (*", outType, ", error) {")
+		d.P("return x.recv()")
+		d.P("}")
+		d.P()
+	}
+	if genCloseAndRecv {
+		d.P("func (x *", mock, ") CloseAndRecv() (*", outType, ", error) {")
+		d.P("if err := x.CloseSend(); err != nil { return nil, err }")
+		d.P("return x.recv()")
+		d.P("}")
+		d.P()
+	}
+}
@@ -0,0 +1,450 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//
+// openapi document types
+//
+
+type openAPI struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       openAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	In          string  `json:"in,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *schema `json:"schema,omitempty"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AllOf                []*schema          `json:"allOf,omitempty"`
+}
+
+type components struct {
+	Schemas    map[string]*schema    `json:"schemas"`
+	Parameters map[string]*parameter `json:"parameters"`
+	Responses  map[string]*response  `json:"responses"`
+}
+
+//
+// openapi generation
+//
+
+const (
+	errorName    = "drpchttp.Error"
+	metadataName = "drpchttp.Metadata"
+	jsonType     = "application/json"
+)
+
+func generateOpenAPIFile(plugin *protogen.Plugin, file *protogen.File) error {
+	o := &openAPIGen{
+		doc: openAPI{
+			OpenAPI: "3.0.3",
+			Info: openAPIInfo{
+				Title:   string(file.Desc.Package()),
+				Version: "1.0.0",
+			},
+			Paths: map[string]map[string]*operation{},
+			Components: components{
+				Schemas: map[string]*schema{
+					errorName: {
+						Type: "object",
+						Description: "The body of every error response. Its status code depends on the code, " +
+							"like 404 for \"not_found\", and is 500 if the code is \"unknown\" or a drpcerr code " +
+							"like \"drpcerr(5)\".",
+						Properties: map[string]*schema{
+							"code": {Type: "string", Description: "A short string describing the kind of error."},
+							"msg":  {Type: "string", Description: "A textual description of the error."},
+						},
+						Required: []string{"code", "msg"},
+					},
+				},
+				Parameters: map[string]*parameter{
+					metadataName: {
+						Name: "X-Drpc-Metadata",
+						In:   "header",
+						Description: "Metadata to attach to the RPC, as percentEncode(key)=percentEncode(value). " +
+							"It may be sent multiple times.",
+						Schema: &schema{Type: "string"},
+					},
+				},
+				Responses: map[string]*response{
+					errorName: {
+						Description: "The RPC failed.",
+						Content:     map[string]mediaType{jsonType: {Schema: schemaRef(errorName)}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+				continue
+			}
+			if err := o.addMethod(method); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(o.doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gf := plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+".openapi.json", "")
+	_, err = gf.Write(append(data, '\n'))
+	return err
+}
+
+type openAPIGen struct {
+	doc openAPI
+}
+
+func schemaRef(name string) *schema {
+	return &schema{Ref: "#/components/schemas/" + name}
+}
+
+// addMethod adds the operations for the RPC path and any routes of the method.
+func (o *openAPIGen) addMethod(method *protogen.Method) error {
+	routes, err := httpRoutes(method)
+	if err != nil {
+		return err
+	}
+
+	op := o.newOperation(method, string(method.Desc.FullName()))
+	op.RequestBody = &requestBody{
+		Required: true,
+		Content:  map[string]mediaType{jsonType: {Schema: o.messageSchema(method.Input)}},
+	}
+	op.Responses["200"].Content[jsonType] = mediaType{Schema: o.messageSchema(method.Output)}
+	o.addOperation(rpcName(method), "post", op)
+
+	for i, route := range routes {
+		op := o.newOperation(method, fmt.Sprintf("%s.http%d", method.Desc.FullName(), i))
+		path := templateVariable.ReplaceAllString(route.Pattern, "{$1}")
+
+		bound := map[string]bool{}
+		for _, m := range templateVariable.FindAllStringSubmatch(route.Pattern, -1) {
+			bound[m[1]] = true
+			param := &parameter{
+				Name:     m[1],
+				In:       "path",
+				Required: true,
+				Schema:   o.fieldPathSchema(method.Input, m[1]),
+			}
+			if m[2] != "" {
+				param.Description = "The path segments matching " + m[2][1:] + "."
+			}
+			op.Parameters = append(op.Parameters, param)
+		}
+
+		switch route.Body {
+		case "":
+		case "*":
+			op.RequestBody = &requestBody{
+				Required: true,
+				Content:  map[string]mediaType{jsonType: {Schema: o.messageSchema(method.Input)}},
+			}
+		default:
+			op.RequestBody = &requestBody{
+				Content: map[string]mediaType{jsonType: {Schema: o.fieldPathSchema(method.Input, route.Body)}},
+			}
+		}
+
+		if route.Body != "*" {
+			for _, field := range method.Input.Fields {
+				name := string(field.Desc.Name())
+				if bound[name] || name == route.Body {
+					continue
+				}
+				if field.Desc.IsMap() || !queryable(field) {
+					continue
+				}
+				op.Parameters = append(op.Parameters, &parameter{
+					Name:   field.Desc.JSONName(),
+					In:     "query",
+					Schema: o.fieldSchema(field),
+				})
+			}
+		}
+
+		if route.ResponseBody == "" {
+			op.Responses["200"].Content[jsonType] = mediaType{Schema: o.messageSchema(method.Output)}
+		} else {
+			op.Responses["200"].Content[jsonType] = mediaType{Schema: o.fieldPathSchema(method.Output, route.ResponseBody)}
+		}
+
+		method := strings.ToLower(route.Method)
+		switch method {
+		case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+			o.addOperation(path, method, op)
+		}
+	}
+
+	return nil
+}
+
+// templateVariable matches a variable in a path template, capturing its field
+// path.
+var templateVariable = regexp.MustCompile(`{([^=}]+)(=[^}]*)?}`)
+
+func (o *openAPIGen) newOperation(method *protogen.Method, id string) *operation {
+	return &operation{
+		OperationID: id,
+		Description: commentText(method.Comments.Leading),
+		Tags:        []string{string(method.Parent.Desc.FullName())},
+		Parameters:  []*parameter{{Ref: "#/components/parameters/" + metadataName}},
+		Responses: map[string]*response{
+			"200":     {Description: "The RPC succeeded.", Content: map[string]mediaType{}},
+			"default": {Ref: "#/components/responses/" + errorName},
+		},
+	}
+}
+
+// addOperation adds the operation unless the path already has one for the
+// method.
+func (o *openAPIGen) addOperation(path, method string, op *operation) {
+	ops := o.doc.Paths[path]
+	if ops == nil {
+		ops = map[string]*operation{}
+		o.doc.Paths[path] = ops
+	}
+	if ops[method] == nil {
+		ops[method] = op
+	}
+}
+
+// messageSchema returns a schema for the message, adding it and any messages
+// and enums it refers to to the components.
+func (o *openAPIGen) messageSchema(msg *protogen.Message) *schema {
+	if s, ok := wellKnownSchema(msg.Desc.FullName()); ok {
+		return s
+	}
+
+	name := string(msg.Desc.FullName())
+	if _, ok := o.doc.Components.Schemas[name]; !ok {
+		s := &schema{
+			Type:        "object",
+			Description: commentText(msg.Comments.Leading),
+			Properties:  map[string]*schema{},
+		}
+		o.doc.Components.Schemas[name] = s
+		for _, field := range msg.Fields {
+			s.Properties[field.Desc.JSONName()] = o.fieldSchema(field)
+		}
+	}
+	return schemaRef(name)
+}
+
+// enumSchema returns a schema for the enum, adding it to the components.
+func (o *openAPIGen) enumSchema(enum *protogen.Enum) *schema {
+	name := string(enum.Desc.FullName())
+	if _, ok := o.doc.Components.Schemas[name]; !ok {
+		s := &schema{Type: "string", Description: commentText(enum.Comments.Leading)}
+		for _, value := range enum.Values {
+			s.Enum = append(s.Enum, string(value.Desc.Name()))
+		}
+		o.doc.Components.Schemas[name] = s
+	}
+	return schemaRef(name)
+}
+
+// fieldSchema returns a schema for the JSON encoding of the field.
+func (o *openAPIGen) fieldSchema(field *protogen.Field) *schema {
+	if field.Desc.IsMap() {
+		return &schema{
+			Type:                 "object",
+			Description:          commentText(field.Comments.Leading),
+			AdditionalProperties: o.valueSchema(field.Message.Fields[1]),
+		}
+	}
+
+	s := o.valueSchema(field)
+	if field.Desc.IsList() {
+		s = &schema{Type: "array", Items: s}
+	}
+	if desc := commentText(field.Comments.Leading); desc != "" {
+		if s.Ref != "" {
+			// siblings of $ref are ignored, so wrap it to describe it.
+			s = &schema{AllOf: []*schema{s}}
+		}
+		s.Description = desc
+	}
+	return s
+}
+
+// valueSchema returns a schema for a single value of the field.
+func (o *openAPIGen) valueSchema(field *protogen.Field) *schema {
+	switch field.Desc.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return o.messageSchema(field.Message)
+	case protoreflect.EnumKind:
+		return o.enumSchema(field.Enum)
+	default:
+		return scalarSchema(field.Desc.Kind())
+	}
+}
+
+// fieldPathSchema returns a schema for the field at the dot separated path in
+// the message, or a string schema if there is no such field.
+func (o *openAPIGen) fieldPathSchema(msg *protogen.Message, path string) *schema {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		var found *protogen.Field
+		for _, field := range msg.Fields {
+			if string(field.Desc.Name()) == name || field.Desc.JSONName() == name {
+				found = field
+			}
+		}
+		switch {
+		case found == nil:
+			return &schema{Type: "string"}
+		case i == len(names)-1:
+			return o.fieldSchema(found)
+		case found.Message == nil:
+			return &schema{Type: "string"}
+		}
+		msg = found.Message
+	}
+	return &schema{Type: "string"}
+}
+
+// queryable returns true if values of the field can be query parameters.
+func queryable(field *protogen.Field) bool {
+	if field.Message == nil {
+		return true
+	}
+	s, ok := wellKnownSchema(field.Message.Desc.FullName())
+	return ok && s.Type != "" && s.Type != "object" && s.Type != "array"
+}
+
+// scalarSchema returns the schema for the JSON encoding of a scalar kind.
+func scalarSchema(kind protoreflect.Kind) *schema {
+	switch kind {
+	case protoreflect.BoolKind:
+		return &schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &schema{Type: "integer", Format: "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &schema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &schema{Type: "string", Format: "byte"}
+	default:
+		return &schema{Type: "string"}
+	}
+}
+
+// wellKnownSchema returns the schema for the special JSON encoding of a well
+// known type.
+func wellKnownSchema(name protoreflect.FullName) (*schema, bool) {
+	switch name {
+	case "google.protobuf.Timestamp":
+		return &schema{Type: "string", Format: "date-time"}, true
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return &schema{Type: "string"}, true
+	case "google.protobuf.Empty", "google.protobuf.Struct":
+		return &schema{Type: "object"}, true
+	case "google.protobuf.Any":
+		return &schema{Type: "object", Properties: map[string]*schema{"@type": {Type: "string"}}}, true
+	case "google.protobuf.Value":
+		return &schema{}, true
+	case "google.protobuf.ListValue":
+		return &schema{Type: "array", Items: &schema{}}, true
+	case "google.protobuf.BoolValue":
+		return scalarSchema(protoreflect.BoolKind), true
+	case "google.protobuf.Int32Value":
+		return scalarSchema(protoreflect.Int32Kind), true
+	case "google.protobuf.UInt32Value":
+		return scalarSchema(protoreflect.Uint32Kind), true
+	case "google.protobuf.Int64Value":
+		return scalarSchema(protoreflect.Int64Kind), true
+	case "google.protobuf.UInt64Value":
+		return scalarSchema(protoreflect.Uint64Kind), true
+	case "google.protobuf.FloatValue":
+		return scalarSchema(protoreflect.FloatKind), true
+	case "google.protobuf.DoubleValue":
+		return scalarSchema(protoreflect.DoubleKind), true
+	case "google.protobuf.StringValue":
+		return scalarSchema(protoreflect.StringKind), true
+	case "google.protobuf.BytesValue":
+		return scalarSchema(protoreflect.BytesKind), true
+	default:
+		return nil, false
+	}
+}
+
+// commentText returns the text of the comments without leading and trailing
+// space on each line.
+func commentText(c protogen.Comments) string {
+	lines := strings.Split(strings.TrimSpace(string(c)), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+func TestOpenAPI(t *testing.T) {
+	files := runPlugin(t, config{protolib: "google.golang.org/protobuf", openapi: true}, testFile())
+
+	var doc openAPI
+	assert.NoError(t, json.Unmarshal([]byte(files["lib.openapi.json"]), &doc))
+
+	// streaming rpcs are not served as json, so only GetBook is described.
+	paths := make(map[string][]string)
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			paths[path] = append(paths[path], method)
+		}
+	}
+	assert.DeepEqual(t, paths, map[string][]string{
+		"/lib.Library/GetBook": {"post"},
+		"/v1/{name}":           {"get"},
+		"/v1/books":            {"patch"},
+	})
+
+	// the rpc path takes the whole request message as the body.
+	rpc := doc.Paths["/lib.Library/GetBook"]["post"]
+	assert.Equal(t, rpc.RequestBody.Content[jsonType].Schema.Ref, "#/components/schemas/lib.GetBookRequest")
+	assert.Equal(t, rpc.Responses["200"].Content[jsonType].Schema.Ref, "#/components/schemas/lib.Book")
+	assert.Equal(t, rpc.Responses["default"].Ref, "#/components/responses/drpchttp.Error")
+
+	// fields not bound by the path become query parameters, except messages.
+	get := doc.Paths["/v1/{name}"]["get"]
+	assert.Nil(t, get.RequestBody)
+	var params []string
+	for _, p := range get.Parameters {
+		params = append(params, p.In+":"+p.Name)
+	}
+	assert.DeepEqual(t, params, []string{":", "path:name", "query:pageSize", "query:view"})
+	assert.Equal(t, get.Parameters[2].Schema.Type, "string")
+	assert.Equal(t, get.Parameters[2].Schema.Format, "int64")
+
+	// the selected response field is described.
+	patch := doc.Paths["/v1/books"]["patch"]
+	assert.Equal(t, len(patch.Parameters), 1)
+	assert.Equal(t, patch.Responses["200"].Content[jsonType].Schema.Type, "string")
+
+	// messages and enums use json names and are shared components.
+	assert.Equal(t, doc.Components.Schemas["lib.GetBookRequest"].Properties["pageSize"].Format, "int64")
+	assert.DeepEqual(t, doc.Components.Schemas["lib.View"].Enum, []string{"BASIC", "FULL"})
+	assert.Equal(t, doc.Components.Schemas["lib.GetBookRequest"].Properties["filter"].Ref, "#/components/schemas/lib.Book")
+}
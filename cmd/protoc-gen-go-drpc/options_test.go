@@ -0,0 +1,57 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGenerateMethodOptions(t *testing.T) {
+	file := testFile()
+	file.Service[0].Method[1].Options = nil
+	opts := file.Service[0].Method[0].Options
+	opts.Deprecated = proto.Bool(true)
+
+	buf, err := proto.MarshalOptions{Deterministic: true}.Marshal(opts)
+	assert.NoError(t, err)
+
+	// only the methods with options are listed.
+	files := runPlugin(t, config{protolib: "google.golang.org/protobuf"}, file)
+	assert.That(t, strings.Contains(files["lib_drpc.pb.go"], `func (DRPCLibraryDescription) MethodOptions(n int) []byte {
+	switch n {
+	case 0:
+		return []byte(`+strconv.Quote(string(buf))+`)
+	default:
+		return nil
+	}
+}`))
+
+	// descriptions without options do not implement drpc.DescriptionOptions.
+	file.Service[0].Method[0].Options = nil
+	files = runPlugin(t, config{protolib: "google.golang.org/protobuf"}, file)
+	assert.That(t, !strings.Contains(files["lib_drpc.pb.go"], "MethodOptions"))
+}
+
+func TestGenerateMethodInfo(t *testing.T) {
+	files := runPlugin(t, config{protolib: "google.golang.org/protobuf"}, testFile())
+	assert.That(t, strings.Contains(files["lib_drpc.pb.go"], `	case 1:
+		return drpc.MethodInfo{
+			Service:    "lib.Library",
+			Method:     "WatchBook",
+			Kind:       drpc.MethodServerStream,
+			NewIn:      func() drpc.Message { return new(GetBookRequest) },
+			NewOut:     func() drpc.Message { return new(Book) },
+			Descriptor: File_lib_proto.Services().Get(0).Methods().Get(1),
+		}, true`))
+
+	// other protobuf libraries have no descriptors to reference.
+	files = runPlugin(t, config{protolib: "github.com/gogo/protobuf"}, testFile())
+	assert.That(t, strings.Contains(files["lib_drpc.pb.go"], "drpc.MethodServerStream,"))
+	assert.That(t, !strings.Contains(files["lib_drpc.pb.go"], "Descriptor:"))
+}
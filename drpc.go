@@ -82,6 +82,80 @@ type Description interface {
 	Method(n int) (rpc string, encoding Encoding, receiver Receiver, method interface{}, ok bool)
 }
 
+// DescriptionOptions is an optional interface implemented by a Description
+// that knows the options declared on its methods, so that they can be acted on
+// at runtime.
+type DescriptionOptions interface {
+	// MethodOptions returns the options of the nth method in the wire format of
+	// a google.protobuf.MethodOptions message, including any custom options
+	// as extension fields. It returns nil if the method has no options.
+	MethodOptions(n int) []byte
+}
+
+// MethodKind is the streaming kind of a method.
+type MethodKind uint8
+
+const (
+	// MethodUnary is a method that receives one message and sends one message.
+	MethodUnary MethodKind = iota
+
+	// MethodClientStream is a method that receives a stream of messages and
+	// sends one message.
+	MethodClientStream
+
+	// MethodServerStream is a method that receives one message and sends a
+	// stream of messages.
+	MethodServerStream
+
+	// MethodBidiStream is a method that receives and sends streams of messages.
+	MethodBidiStream
+)
+
+// String returns a human readable form of the MethodKind.
+func (k MethodKind) String() string {
+	switch k {
+	case MethodUnary:
+		return "unary"
+	case MethodClientStream:
+		return "client stream"
+	case MethodServerStream:
+		return "server stream"
+	case MethodBidiStream:
+		return "bidi stream"
+	default:
+		return "unknown"
+	}
+}
+
+// MethodInfo describes the shape of a method of a Description.
+type MethodInfo struct {
+	// Service is the fully qualified name of the service, like
+	// "package.Service", and Method is the name of the method in it.
+	Service string
+	Method  string
+
+	// Kind is the streaming kind of the method.
+	Kind MethodKind
+
+	// NewIn and NewOut return new, empty messages of the types that the
+	// method receives and sends.
+	NewIn  func() Message
+	NewOut func() Message
+
+	// Descriptor is an optional descriptor of the method, like a
+	// protoreflect.MethodDescriptor. It is nil if the protobuf library the
+	// Description was generated for does not provide one.
+	Descriptor interface{}
+}
+
+// DescriptionInfo is an optional interface implemented by a Description that
+// knows the shape of its methods, so that they can be registered and served
+// without inspecting the method expressions with reflection.
+type DescriptionInfo interface {
+	// MethodInfo returns information about the nth method.
+	MethodInfo(n int) (info MethodInfo, ok bool)
+}
+
 // Mux is a type that can have an implementation and a Description registered with it.
 type Mux interface {
 	// Register marks that the description should dispatch RPCs that it describes to
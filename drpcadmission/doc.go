@@ -0,0 +1,11 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcadmission provides an adaptive concurrency limiter for servers.
+//
+// The Limiter measures the latency of every rpc and adjusts how many rpcs may
+// run concurrently using additive increase and multiplicative decrease. RPCs
+// in excess of the limit are rejected before their handler runs with an error
+// carrying the drpcerr.Unavailable code. It is used by setting it as the
+// Admission field of drpcserver.Options.
+package drpcadmission
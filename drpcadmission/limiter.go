@@ -0,0 +1,156 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcadmission
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc/drpcerr"
+)
+
+// Options controls configuration settings for a Limiter.
+type Options struct {
+	// Target is the latency that rpcs are expected to complete within. Any
+	// rpc that takes longer causes the limit to decrease, unless it was
+	// admitted before the last decrease, so that many concurrent slow rpcs
+	// only decrease it once. If zero, 100 milliseconds is used.
+	Target time.Duration
+
+	// Initial is the starting concurrency limit. If zero, 20 is used.
+	Initial int
+
+	// Min is the smallest the concurrency limit may become. If zero, 1 is used.
+	Min int
+
+	// Max is the largest the concurrency limit may become. If zero, 1000 is
+	// used.
+	Max int
+
+	// Backoff is the factor the limit is multiplied by when it decreases. It
+	// must be between 0 and 1. If zero, 0.9 is used.
+	Backoff float64
+
+	// Reserve is the fraction of the limit that is only available to critical
+	// rpcs, so that they are shed last. If zero, no capacity is reserved.
+	Reserve float64
+
+	// Critical returns true if the rpc is critical. If nil, no rpcs are
+	// critical.
+	Critical func(ctx context.Context, rpc string) bool
+}
+
+// Limiter is an adaptive concurrency limiter. It implements the
+// drpcserver.Admission interface.
+type Limiter struct {
+	opts Options
+	now  func() time.Time
+
+	mu        sync.Mutex
+	limit     float64
+	inflight  int
+	decreased time.Time
+}
+
+// New returns a new Limiter using the provided options.
+func New(opts Options) *Limiter {
+	if opts.Target <= 0 {
+		opts.Target = 100 * time.Millisecond
+	}
+	if opts.Min <= 0 {
+		opts.Min = 1
+	}
+	if opts.Max <= 0 {
+		opts.Max = 1000
+	}
+	if opts.Initial <= 0 {
+		opts.Initial = 20
+	}
+	if opts.Backoff <= 0 || opts.Backoff >= 1 {
+		opts.Backoff = 0.9
+	}
+
+	return &Limiter{
+		opts:  opts,
+		now:   time.Now,
+		limit: clamp(float64(opts.Initial), float64(opts.Min), float64(opts.Max)),
+	}
+}
+
+// Limit returns the current concurrency limit.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.limit)
+}
+
+// InFlight returns the number of admitted rpcs that have not finished.
+func (l *Limiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inflight
+}
+
+// Admit admits the rpc if there is capacity for it, returning a callback that
+// must be called with the result of the rpc when it is finished. If there is
+// no capacity, an error with the drpcerr.Unavailable code is returned.
+func (l *Limiter) Admit(ctx context.Context, rpc string) (done func(error), err error) {
+	critical := l.opts.Critical != nil && l.opts.Critical(ctx, rpc)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	capacity := l.limit
+	if !critical {
+		capacity *= 1 - l.opts.Reserve
+	}
+	if float64(l.inflight) >= capacity {
+		return nil, drpcerr.WithCode(
+			errs.New("server overloaded: %d rpcs in flight", l.inflight),
+			drpcerr.Unavailable)
+	}
+
+	l.inflight++
+	start, inflight := l.now(), l.inflight
+
+	return func(err error) { l.release(start, inflight, err) }, nil
+}
+
+// release records the result of an admitted rpc and adjusts the limit.
+func (l *Limiter) release(start time.Time, inflight int, err error) {
+	latency := l.now().Sub(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+
+	switch {
+	case latency > l.opts.Target, drpcerr.Code(err) == drpcerr.Unavailable:
+		// rpcs admitted before the last decrease were already accounted for
+		// by it, so they do not decrease the limit again.
+		if !start.Before(l.decreased) {
+			l.limit *= l.opts.Backoff
+			l.decreased = l.now()
+		}
+
+	// only increase the limit if the rpc was using a meaningful portion of
+	// it, otherwise an idle server would grow its limit without bound.
+	case 2*float64(inflight) >= l.limit:
+		l.limit += 1 / l.limit
+	}
+
+	l.limit = clamp(l.limit, float64(l.opts.Min), float64(l.opts.Max))
+}
+
+// clamp returns x limited to be within [lo, hi].
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
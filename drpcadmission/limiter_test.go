@@ -0,0 +1,94 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcadmission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc/drpcerr"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := New(Options{
+		Target:  time.Second,
+		Initial: 4,
+		Min:     2,
+		Reserve: 0.5,
+		Critical: func(ctx context.Context, rpc string) bool {
+			return rpc == "critical"
+		},
+	})
+	l.now = func() time.Time { return now }
+
+	// non-critical rpcs only get half of the limit.
+	var dones []func(error)
+	for i := 0; i < 2; i++ {
+		done, err := l.Admit(context.Background(), "normal")
+		assert.NoError(t, err)
+		dones = append(dones, done)
+	}
+	_, err := l.Admit(context.Background(), "normal")
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.Unavailable)
+
+	// critical rpcs can use the rest.
+	for i := 0; i < 2; i++ {
+		done, err := l.Admit(context.Background(), "critical")
+		assert.NoError(t, err)
+		dones = append(dones, done)
+	}
+	_, err = l.Admit(context.Background(), "critical")
+	assert.Error(t, err)
+	assert.Equal(t, l.InFlight(), 4)
+
+	// fast rpcs that used the limit increase it.
+	for _, done := range dones {
+		done(nil)
+	}
+	assert.Equal(t, l.InFlight(), 0)
+	assert.Equal(t, l.Limit(), 4)
+	assert.That(t, l.limit > 4)
+
+	// slow rpcs decrease it, but not below the minimum.
+	for i := 0; i < 20; i++ {
+		done, err := l.Admit(context.Background(), "critical")
+		assert.NoError(t, err)
+		now = now.Add(2 * time.Second)
+		done(nil)
+	}
+	assert.Equal(t, l.Limit(), 2)
+}
+
+func TestLimiterConcurrentDecrease(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := New(Options{Target: time.Second, Initial: 100})
+	l.now = func() time.Time { return now }
+
+	var dones []func(error)
+	for i := 0; i < 50; i++ {
+		done, err := l.Admit(context.Background(), "rpc")
+		assert.NoError(t, err)
+		dones = append(dones, done)
+	}
+
+	// many concurrent slow rpcs only decrease the limit once.
+	now = now.Add(2 * time.Second)
+	for _, done := range dones {
+		done(nil)
+	}
+	assert.Equal(t, l.Limit(), 90)
+
+	// rpcs admitted after the decrease can decrease it again.
+	done, err := l.Admit(context.Background(), "rpc")
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Second)
+	done(drpcerr.WithCode(errs.New("overloaded"), drpcerr.Unavailable))
+	assert.Equal(t, l.Limit(), 81)
+}
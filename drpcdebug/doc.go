@@ -2,6 +2,16 @@
 // See LICENSE for copying information.
 
 // Package drpcdebug provides helpers for debugging.
+//
+// The managers, streams and writers of connections write debug events to a
+// Tracer, which is Global unless configured otherwise. Tracing can be enabled
+// at runtime for every rpc or only specific ones, and a separate Tracer can be
+// used for specific connections:
+//
+//	drpcdebug.Global.Enable("/service.Service/Method")
+//	defer drpcdebug.Global.Disable()
+//
+// Building with the debug tag enables Global from the start.
 package drpcdebug
 
 // Enabled is a constant describing if logs are enabled or not.
@@ -16,6 +16,8 @@ import (
 
 var logger = log.New(os.Stderr, "", 0)
 
+func init() { Global.Enable() }
+
 // Log executes the callback for a string to log if built with the debug tag.
 func Log(cb func() (who, what, why string)) {
 	_, file, line, _ := runtime.Caller(1)
@@ -0,0 +1,107 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcdebug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+)
+
+// Global is the Tracer used by anything that has not been configured with a
+// different Tracer. It writes to stderr and is enabled from the start when
+// built with the debug tag.
+var Global = NewTracer(os.Stderr)
+
+// Tracer writes debug events, like the packets read and the frames sent by
+// streams, for the connections it is used by. It can be enabled and disabled
+// at runtime and limited to specific rpcs. Checking a nil or disabled Tracer
+// costs a single atomic load.
+type Tracer struct {
+	state  atomic.Pointer[traceState]
+	logger *log.Logger
+}
+
+// traceState is the set of rpcs a Tracer is enabled for, or nil for all of
+// them.
+type traceState struct {
+	rpcs map[string]struct{}
+}
+
+// NewTracer returns a disabled Tracer that writes events to w.
+func NewTracer(w io.Writer) *Tracer {
+	return &Tracer{logger: log.New(w, "", 0)}
+}
+
+// Enable enables tracing for the rpcs, or every event if none are provided.
+// Events that are not about a specific rpc, like the connection terminating,
+// are only traced when no rpcs are provided.
+func (t *Tracer) Enable(rpcs ...string) {
+	var st traceState
+	if len(rpcs) > 0 {
+		st.rpcs = make(map[string]struct{}, len(rpcs))
+		for _, rpc := range rpcs {
+			st.rpcs[rpc] = struct{}{}
+		}
+	}
+	t.state.Store(&st)
+}
+
+// Disable disables tracing.
+func (t *Tracer) Disable() { t.state.Store(nil) }
+
+// Active returns true if the Tracer is enabled for any events, so that work
+// only needed to find the rpc of an event can be skipped otherwise.
+func (t *Tracer) Active() bool { return t != nil && t.state.Load() != nil }
+
+// Enabled returns true if events about the rpc should be traced. An empty rpc
+// is used for events that are not about a specific rpc.
+func (t *Tracer) Enabled(rpc string) bool {
+	if t == nil {
+		return false
+	}
+	st := t.state.Load()
+	if st == nil {
+		return false
+	} else if st.rpcs == nil {
+		return true
+	}
+	_, ok := st.rpcs[rpc]
+	return ok
+}
+
+// Log writes the event returned by the callback if events about the rpc are
+// enabled. It is meant to be called from a logging helper, and includes the
+// location that called the helper in the event.
+func (t *Tracer) Log(rpc string, cb func() (who, what, why string)) {
+	if !t.Enabled(rpc) {
+		return
+	}
+	_, file, line, _ := runtime.Caller(2)
+	where := fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	who, what, why := cb()
+	_ = t.logger.Output(2, fmt.Sprintf("%24s | %-32s | %-6s | %s",
+		where, who, what, why))
+}
+
+// tracerKey is the context key for a Tracer.
+type tracerKey struct{}
+
+// WithTracer returns a context that carries the Tracer. Servers use the
+// Tracer in the context passed to ServeOne for that connection, which allows
+// tracing to be scoped to specific connections.
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// TracerFrom returns the Tracer carried by the context, if any.
+func TracerFrom(ctx context.Context) (*Tracer, bool) {
+	t, ok := ctx.Value(tracerKey{}).(*Tracer)
+	return t, ok
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcdebug
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+)
+
+func TestTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTracer(&buf)
+	log := func(rpc string) {
+		tr.Log(rpc, func() (_, _, _ string) { return "who", "WHAT", "why " + rpc })
+	}
+
+	// disabled tracers trace nothing
+	assert.That(t, !tr.Enabled(""))
+	assert.That(t, !(*Tracer)(nil).Enabled(""))
+	assert.That(t, !tr.Active())
+	log("a")
+	assert.Equal(t, buf.Len(), 0)
+
+	// enabled tracers trace everything
+	tr.Enable()
+	assert.That(t, tr.Enabled(""))
+	assert.That(t, tr.Enabled("a"))
+	log("a")
+	assert.That(t, strings.Contains(buf.String(), "| who"))
+	assert.That(t, strings.Contains(buf.String(), "why a"))
+
+	// tracers limited to rpcs only trace those rpcs
+	buf.Reset()
+	tr.Enable("b")
+	assert.That(t, tr.Active())
+	assert.That(t, !tr.Enabled(""))
+	log("a")
+	log("b")
+	assert.That(t, !strings.Contains(buf.String(), "why a"))
+	assert.That(t, strings.Contains(buf.String(), "why b"))
+
+	tr.Disable()
+	assert.That(t, !tr.Enabled("b"))
+
+	// tracers are carried by contexts
+	_, ok := TracerFrom(context.Background())
+	assert.That(t, !ok)
+	got, ok := TracerFrom(WithTracer(context.Background(), tr))
+	assert.That(t, ok)
+	assert.Equal(t, got, tr)
+}
@@ -6,9 +6,29 @@ package drpcerr
 import "unsafe"
 
 const (
+	// DeadlineExceeded is the code used when an rpc is failed because it
+	// took longer than it was allowed to run.
+	DeadlineExceeded = 4
+
+	// NotFound is the code used when an rpc fails because something it
+	// asked for, like a service, does not exist.
+	NotFound = 5
+
+	// ResourceExhausted is the code used when an rpc is rejected because
+	// some resource, like a rate limit, has been exhausted.
+	ResourceExhausted = 8
+
 	// Unimplemented is the code used by the generated unimplemented
 	// servers when returning errors.
 	Unimplemented = 12
+
+	// Internal is the code used when an rpc fails because of an internal
+	// error in the server, like a recovered panic.
+	Internal = 13
+
+	// Unavailable is the code used when an rpc is rejected because the
+	// server is temporarily unable to handle it, like when it is overloaded.
+	Unavailable = 14
 )
 
 // Code returns the error code associated with the error or 0 if none is.
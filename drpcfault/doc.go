@@ -0,0 +1,19 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcfault provides a drpc.Transport that injects faults for testing
+// retries, timeouts and error handling.
+//
+// The faults are configured separately for reads and writes, and can be
+// changed at any time while the transport is in use. All of the randomness
+// comes from a seeded source for each direction, so a failing test can be
+// reproduced by using the same seed. For example,
+//
+//	tr := drpcfault.NewWithOptions(rawConn, drpcfault.Options{
+//		Seed:  seed,
+//		Write: drpcfault.Faults{Latency: 10 * time.Millisecond, ChunkSize: 1},
+//	})
+//	conn := drpcconn.New(tr)
+//	// ... later ...
+//	tr.SetRead(drpcfault.Faults{DropAfterPackets: 1})
+package drpcfault
@@ -0,0 +1,327 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcfault
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpctap"
+)
+
+// Error is the class of errors returned for injected faults.
+var Error = errs.Class("drpcfault")
+
+// Faults describes the faults injected in one direction of a Transport. The
+// zero value injects no faults.
+type Faults struct {
+	// Latency is added to every read or write.
+	Latency time.Duration
+
+	// Jitter is the maximum of a random amount of time added to every read
+	// or write in addition to Latency.
+	Jitter time.Duration
+
+	// Bandwidth is the maximum number of bytes per second. If zero, the
+	// bandwidth is not limited.
+	Bandwidth int
+
+	// DropAfterBytes causes the wrapped transport to be closed once this
+	// many bytes have been transferred. If zero, it is never dropped.
+	DropAfterBytes int64
+
+	// DropAfterPackets causes the wrapped transport to be closed after the
+	// read or write that completes this many packets. If zero, it is never
+	// dropped.
+	DropAfterPackets int64
+
+	// Corrupt is the probability that each byte transferred has a random bit
+	// flipped.
+	Corrupt float64
+
+	// ChunkSize is the maximum number of bytes passed to a single read or
+	// write of the wrapped transport. If zero, the size is not limited.
+	ChunkSize int
+
+	// StallAfterBytes causes reads or writes to block until the Transport
+	// is closed once this many bytes have been transferred. If zero, they
+	// never stall.
+	StallAfterBytes int64
+}
+
+// Options controls configuration settings for a Transport.
+type Options struct {
+	// Read is the faults injected into reads.
+	Read Faults
+
+	// Write is the faults injected into writes.
+	Write Faults
+
+	// Seed seeds the random sources used for jitter and corruption.
+	Seed int64
+}
+
+// Transport is a drpc.Transport that injects faults into the reads and writes
+// of the transport it wraps.
+type Transport struct {
+	tr drpc.Transport
+
+	read  direction
+	write direction
+
+	once sync.Once
+	done chan struct{}
+}
+
+var _ drpc.Transport = (*Transport)(nil)
+
+// New returns a Transport that wraps tr without injecting any faults until
+// some are set.
+func New(tr drpc.Transport) *Transport {
+	return NewWithOptions(tr, Options{})
+}
+
+// NewWithOptions returns a Transport that wraps tr and injects the faults in
+// the provided options.
+func NewWithOptions(tr drpc.Transport, opts Options) *Transport {
+	t := &Transport{
+		tr:   tr,
+		done: make(chan struct{}),
+	}
+	t.read.init(drpctap.Read, opts.Read, opts.Seed)
+	t.write.init(drpctap.Write, opts.Write, opts.Seed+1)
+	return t
+}
+
+// Transport returns the wrapped transport.
+func (t *Transport) Transport() drpc.Transport { return t.tr }
+
+// SetRead changes the faults injected into reads. The counts of bytes and
+// packets that the faults refer to start over from zero.
+func (t *Transport) SetRead(faults Faults) { t.read.set(faults) }
+
+// SetWrite changes the faults injected into writes. The counts of bytes and
+// packets that the faults refer to start over from zero.
+func (t *Transport) SetWrite(faults Faults) { t.write.set(faults) }
+
+// Read reads from the wrapped transport, injecting the read faults.
+func (t *Transport) Read(p []byte) (n int, err error) {
+	size, delay, act := t.read.next(len(p))
+	if act != actNone {
+		return 0, t.fault(act)
+	}
+
+	n, err = t.tr.Read(p[:size])
+	wait, drop := t.read.transferred(p[:n], true)
+	if drop {
+		_ = t.tr.Close()
+	}
+	if serr := t.sleep(delay + wait); err == nil {
+		err = serr
+	}
+	return n, err
+}
+
+// Write writes to the wrapped transport, injecting the write faults.
+func (t *Transport) Write(p []byte) (n int, err error) {
+	for n < len(p) {
+		size, delay, act := t.write.next(len(p) - n)
+		if act != actNone {
+			return n, t.fault(act)
+		}
+		if err := t.sleep(delay); err != nil {
+			return n, err
+		}
+
+		buf := t.write.corrupt(p[n : n+size])
+		m, err := t.tr.Write(buf)
+		n += m
+
+		wait, drop := t.write.transferred(p[n-m:n], false)
+		if drop {
+			_ = t.tr.Close()
+		}
+		if err != nil {
+			return n, err
+		}
+		if err := t.sleep(wait); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Close closes the Transport and the wrapped transport, unblocking any
+// stalled reads or writes.
+func (t *Transport) Close() error {
+	t.once.Do(func() { close(t.done) })
+	return t.tr.Close()
+}
+
+// fault performs the action and returns the error for it.
+func (t *Transport) fault(act action) error {
+	if act == actStall {
+		<-t.done
+		return drpc.ClosedError.New("transport closed while stalled")
+	}
+	_ = t.tr.Close()
+	return Error.New("connection dropped")
+}
+
+// sleep waits for the duration or until the Transport is closed.
+func (t *Transport) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-t.done:
+		return drpc.ClosedError.New("transport closed")
+	}
+}
+
+//
+// direction
+//
+
+// action is what to do instead of transferring bytes.
+type action int
+
+const (
+	actNone action = iota
+	actDrop
+	actStall
+)
+
+// direction keeps track of the faults and the state needed to inject them in
+// one direction.
+type direction struct {
+	mu      sync.Mutex
+	faults  Faults
+	rng     *rand.Rand
+	dir     drpctap.Direction
+	parser  *drpctap.Parser
+	bytes   int64
+	packets int64
+}
+
+// init sets up the direction with the faults and a random source seeded with
+// the seed.
+func (d *direction) init(dir drpctap.Direction, faults Faults, seed int64) {
+	d.dir = dir
+	d.rng = rand.New(rand.NewSource(seed)) //nolint: gosec // reproducibility, not security
+	d.set(faults)
+}
+
+// set changes the faults and starts counting bytes and packets from zero.
+func (d *direction) set(faults Faults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.faults = faults
+	d.bytes, d.packets = 0, 0
+
+	// the parser is recreated so that counting starts at the next packet
+	// boundary even if the faults are changed in the middle of one. the sink
+	// is called from Feed which is only called with the mutex held.
+	d.parser = drpctap.NewParser(d.dir, drpctap.SinkFunc(func(ev drpctap.Event) {
+		if ev.Packet != nil {
+			d.packets++
+		}
+	}))
+}
+
+// next returns how many of the next n bytes may be transferred by a single
+// call to the wrapped transport and how long to wait first, or the action to
+// take instead.
+func (d *direction) next(n int) (size int, delay time.Duration, act action) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f := &d.faults
+	switch {
+	case f.StallAfterBytes > 0 && d.bytes >= f.StallAfterBytes:
+		return 0, 0, actStall
+	case f.DropAfterBytes > 0 && d.bytes >= f.DropAfterBytes:
+		return 0, 0, actDrop
+	case f.DropAfterPackets > 0 && d.packets >= f.DropAfterPackets:
+		return 0, 0, actDrop
+	}
+
+	size = n
+	if f.ChunkSize > 0 && size > f.ChunkSize {
+		size = f.ChunkSize
+	}
+	if rem := f.DropAfterBytes - d.bytes; f.DropAfterBytes > 0 && int64(size) > rem {
+		size = int(rem)
+	}
+	if rem := f.StallAfterBytes - d.bytes; f.StallAfterBytes > 0 && int64(size) > rem {
+		size = int(rem)
+	}
+
+	delay = f.Latency
+	if f.Jitter > 0 {
+		delay += time.Duration(d.rng.Int63n(int64(f.Jitter) + 1))
+	}
+
+	return size, delay, actNone
+}
+
+// corrupt returns a copy of buf with random bits flipped if corruption is
+// enabled, and buf unchanged otherwise.
+func (d *direction) corrupt(buf []byte) []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.faults.Corrupt <= 0 {
+		return buf
+	}
+	buf = append([]byte(nil), buf...)
+	d.corruptLocked(buf)
+	return buf
+}
+
+// corruptLocked flips random bits of buf in place. It must be called with
+// the mutex held.
+func (d *direction) corruptLocked(buf []byte) {
+	for i := range buf {
+		if d.rng.Float64() < d.faults.Corrupt {
+			buf[i] ^= 1 << d.rng.Intn(8)
+		}
+	}
+}
+
+// transferred accounts for the bytes that were transferred, and then corrupts
+// them in place if inPlace is set. The bytes must not have been corrupted
+// already so that the packets can be counted. It returns how long to wait to keep within
+// the bandwidth and if the connection should be dropped now.
+func (d *direction) transferred(buf []byte, inPlace bool) (wait time.Duration, drop bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.bytes += int64(len(buf))
+	d.parser.Feed(buf)
+
+	if inPlace && d.faults.Corrupt > 0 {
+		d.corruptLocked(buf)
+	}
+
+	f := &d.faults
+	if f.Bandwidth > 0 {
+		wait = time.Duration(len(buf)) * time.Second / time.Duration(f.Bandwidth)
+	}
+	drop = (f.DropAfterBytes > 0 && d.bytes >= f.DropAfterBytes) ||
+		(f.DropAfterPackets > 0 && d.packets >= f.DropAfterPackets)
+
+	return wait, drop
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcfault
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcwire"
+)
+
+func TestChunkAndCorrupt(t *testing.T) {
+	write := func(seed int64) *transport {
+		tr := new(transport)
+		ft := NewWithOptions(tr, Options{
+			Seed:  seed,
+			Write: Faults{ChunkSize: 3, Corrupt: 0.5},
+		})
+		n, err := ft.Write([]byte("hello world"))
+		assert.NoError(t, err)
+		assert.Equal(t, n, 11)
+		return tr
+	}
+
+	tr := write(1)
+	assert.Equal(t, len(tr.writes), 4)
+	assert.That(t, string(bytes.Join(tr.writes, nil)) != "hello world")
+
+	// the same seed corrupts the same bits.
+	assert.DeepEqual(t, write(1).writes, tr.writes)
+}
+
+func TestDropAfterPackets(t *testing.T) {
+	tr := new(transport)
+	ft := New(tr)
+	wr := drpcwire.NewWriter(ft, 0)
+
+	pkt := drpcwire.Packet{ID: drpcwire.ID{Stream: 1, Message: 1}, Kind: drpcwire.KindMessage}
+	assert.NoError(t, wr.WritePacket(pkt))
+	assert.NoError(t, wr.Flush())
+
+	// counting starts over when the faults are set.
+	ft.SetWrite(Faults{DropAfterPackets: 2})
+
+	pkt.ID.Message++
+	assert.NoError(t, wr.WritePacket(pkt))
+	assert.NoError(t, wr.Flush())
+	assert.That(t, !tr.closed)
+
+	pkt.ID.Message++
+	assert.NoError(t, wr.WritePacket(pkt))
+	assert.NoError(t, wr.Flush())
+	assert.That(t, tr.closed)
+
+	_, err := ft.Write([]byte("x"))
+	assert.That(t, Error.Has(err))
+}
+
+func TestDropAfterBytes(t *testing.T) {
+	tr := &transport{data: []byte("hello world")}
+	ft := NewWithOptions(tr, Options{Read: Faults{DropAfterBytes: 5}})
+
+	buf, err := io.ReadAll(ft)
+	assert.That(t, Error.Has(err))
+	assert.Equal(t, string(buf), "hello")
+	assert.That(t, tr.closed)
+}
+
+func TestStallAndLatency(t *testing.T) {
+	tr := &transport{data: []byte("hello world")}
+	ft := NewWithOptions(tr, Options{Read: Faults{
+		Latency:         10 * time.Millisecond,
+		StallAfterBytes: 5,
+	}})
+
+	start := time.Now()
+	buf := make([]byte, 11)
+	n, err := ft.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, string(buf[:n]), "hello")
+	assert.That(t, time.Since(start) >= 10*time.Millisecond)
+
+	errch := make(chan error, 1)
+	go func() { _, err := ft.Read(buf); errch <- err }()
+
+	select {
+	case err := <-errch:
+		t.Fatal("read did not stall:", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	assert.NoError(t, ft.Close())
+	assert.That(t, drpc.ClosedError.Has(<-errch))
+}
+
+// transport is a drpc.Transport that records writes and reads from data.
+type transport struct {
+	writes [][]byte
+	data   []byte
+	closed bool
+}
+
+func (t *transport) Read(p []byte) (int, error) {
+	if t.closed {
+		return 0, errors.New("closed")
+	} else if len(t.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, t.data)
+	t.data = t.data[n:]
+	return n, nil
+}
+
+func (t *transport) Write(p []byte) (int, error) {
+	if t.closed {
+		return 0, errors.New("closed")
+	}
+	t.writes = append(t.writes, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func (t *transport) Close() error {
+	t.closed = true
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchealth
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc"
+)
+
+// Error is the class of errors returned when a service is not serving.
+var Error = errs.Class("drpchealth")
+
+// Check asks the health service on the connection for the status of the
+// service and returns an error if it is not SERVING.
+func Check(ctx context.Context, conn drpc.Conn, service string) error {
+	resp, err := NewDRPCHealthClient(conn).Check(ctx, &HealthCheckRequest{Service: service})
+	if err != nil {
+		return err
+	}
+	if status := resp.GetStatus(); status != HealthCheckResponse_SERVING {
+		return Error.New("service %q is %s", service, status)
+	}
+	return nil
+}
+
+// Validator returns a function that uses Check to validate that the service is
+// SERVING on a connection. It can be used as the Validate option of drpcpool.
+func Validator(service string) func(ctx context.Context, conn drpc.Conn) error {
+	return func(ctx context.Context, conn drpc.Conn) error {
+		return Check(ctx, conn, service)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpchealth provides a standard health checking service.
+//
+// The service has the same shape as grpc.health.v1, with a Check rpc that
+// returns the current status of a service and a Watch rpc that streams its
+// changes. The Server keeps the status of every service and can be updated at
+// runtime, where the empty service name is the status of the server as a
+// whole. The Check and Validator helpers can be used by clients, like with
+// the Validate option of drpcpool to check idle connections before reuse.
+package drpchealth
+
+//go:generate ../scripts/protoc.sh health.proto
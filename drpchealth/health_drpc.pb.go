@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: (devel)
+// source: storj.io/drpc/drpchealth/health.proto
+
+package drpchealth
+
+import (
+	context "context"
+	errors "errors"
+	protojson "google.golang.org/protobuf/encoding/protojson"
+	proto "google.golang.org/protobuf/proto"
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_storj_io_drpc_drpchealth_health_proto struct{}
+
+func (drpcEncoding_File_storj_io_drpc_drpchealth_health_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpchealth_health_proto) MarshalAppend(buf []byte, msg drpc.Message) ([]byte, error) {
+	return proto.MarshalOptions{}.MarshalAppend(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpchealth_health_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpchealth_health_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	return protojson.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpchealth_health_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return protojson.Unmarshal(buf, msg.(proto.Message))
+}
+
+type DRPCHealthClient interface {
+	DRPCConn() drpc.Conn
+
+	Check(ctx context.Context, in *HealthCheckRequest) (*HealthCheckResponse, error)
+	Watch(ctx context.Context, in *HealthCheckRequest) (DRPCHealth_WatchClient, error)
+}
+
+type drpcHealthClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCHealthClient(cc drpc.Conn) DRPCHealthClient {
+	return &drpcHealthClient{cc}
+}
+
+func (c *drpcHealthClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcHealthClient) Check(ctx context.Context, in *HealthCheckRequest) (*HealthCheckResponse, error) {
+	out := new(HealthCheckResponse)
+	err := c.cc.Invoke(ctx, "/drpc.health.v1.Health/Check", drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcHealthClient) Watch(ctx context.Context, in *HealthCheckRequest) (DRPCHealth_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, "/drpc.health.v1.Health/Watch", drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{})
+	if err != nil {
+		return nil, err
+	}
+	x := &drpcHealth_WatchClient{stream}
+	if err := x.MsgSend(in, drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{}); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DRPCHealth_WatchClient interface {
+	drpc.Stream
+	Recv() (*HealthCheckResponse, error)
+}
+
+type drpcHealth_WatchClient struct {
+	drpc.Stream
+}
+
+func (x *drpcHealth_WatchClient) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcHealth_WatchClient) Recv() (*HealthCheckResponse, error) {
+	m := new(HealthCheckResponse)
+	if err := x.MsgRecv(m, drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *drpcHealth_WatchClient) RecvMsg(m *HealthCheckResponse) error {
+	return x.MsgRecv(m, drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{})
+}
+
+type DRPCHealthServer interface {
+	Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	Watch(*HealthCheckRequest, DRPCHealth_WatchStream) error
+}
+
+type DRPCHealthUnimplementedServer struct{}
+
+func (s *DRPCHealthUnimplementedServer) Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCHealthUnimplementedServer) Watch(*HealthCheckRequest, DRPCHealth_WatchStream) error {
+	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCHealthDescription struct{}
+
+func (DRPCHealthDescription) NumMethods() int { return 2 }
+
+func (DRPCHealthDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/drpc.health.v1.Health/Check", drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCHealthServer).
+					Check(
+						ctx,
+						in1.(*HealthCheckRequest),
+					)
+			}, DRPCHealthServer.Check, true
+	case 1:
+		return "/drpc.health.v1.Health/Watch", drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return nil, srv.(DRPCHealthServer).
+					Watch(
+						in1.(*HealthCheckRequest),
+						&drpcHealth_WatchStream{in2.(drpc.Stream)},
+					)
+			}, DRPCHealthServer.Watch, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func (DRPCHealthDescription) MethodInfo(n int) (drpc.MethodInfo, bool) {
+	switch n {
+	case 0:
+		return drpc.MethodInfo{
+			Service:    "drpc.health.v1.Health",
+			Method:     "Check",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(HealthCheckRequest) },
+			NewOut:     func() drpc.Message { return new(HealthCheckResponse) },
+			Descriptor: File_storj_io_drpc_drpchealth_health_proto.Services().Get(0).Methods().Get(0),
+		}, true
+	case 1:
+		return drpc.MethodInfo{
+			Service:    "drpc.health.v1.Health",
+			Method:     "Watch",
+			Kind:       drpc.MethodServerStream,
+			NewIn:      func() drpc.Message { return new(HealthCheckRequest) },
+			NewOut:     func() drpc.Message { return new(HealthCheckResponse) },
+			Descriptor: File_storj_io_drpc_drpchealth_health_proto.Services().Get(0).Methods().Get(1),
+		}, true
+	default:
+		return drpc.MethodInfo{}, false
+	}
+}
+
+func DRPCRegisterHealth(mux drpc.Mux, impl DRPCHealthServer) error {
+	return mux.Register(impl, DRPCHealthDescription{})
+}
+
+type DRPCHealth_CheckStream interface {
+	drpc.Stream
+	SendAndClose(*HealthCheckResponse) error
+}
+
+type drpcHealth_CheckStream struct {
+	drpc.Stream
+}
+
+func (x *drpcHealth_CheckStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcHealth_CheckStream) SendAndClose(m *HealthCheckResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCHealth_WatchStream interface {
+	drpc.Stream
+	Send(*HealthCheckResponse) error
+}
+
+type drpcHealth_WatchStream struct {
+	drpc.Stream
+}
+
+func (x *drpcHealth_WatchStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcHealth_WatchStream) Send(m *HealthCheckResponse) error {
+	return x.MsgSend(m, drpcEncoding_File_storj_io_drpc_drpchealth_health_proto{})
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchealth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+)
+
+func TestCheck(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	srv := NewServer()
+	conn := serveConn(t, ctx, srv)
+	defer func() { _ = conn.Close() }()
+
+	assert.NoError(t, Check(ctx, conn, ""))
+
+	srv.SetServingStatus("svc", HealthCheckResponse_NOT_SERVING)
+	assert.That(t, Error.Has(Check(ctx, conn, "svc")))
+
+	srv.SetServingStatus("svc", HealthCheckResponse_SERVING)
+	assert.NoError(t, Check(ctx, conn, "svc"))
+
+	err := Check(ctx, conn, "unknown")
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.NotFound)
+
+	srv.Shutdown()
+	assert.Error(t, Check(ctx, conn, ""))
+	srv.SetServingStatus("", HealthCheckResponse_SERVING)
+	assert.Error(t, Check(ctx, conn, ""))
+
+	srv.Resume()
+	assert.NoError(t, Validator("svc")(ctx, conn))
+}
+
+func TestWatch(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	srv := NewServer()
+	conn := serveConn(t, ctx, srv)
+	defer func() { _ = conn.Close() }()
+
+	stream, err := NewDRPCHealthClient(conn).Watch(ctx, &HealthCheckRequest{Service: "svc"})
+	assert.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	recv := func() HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := stream.Recv()
+		assert.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	assert.Equal(t, recv(), HealthCheckResponse_SERVICE_UNKNOWN)
+
+	srv.SetServingStatus("svc", HealthCheckResponse_SERVING)
+	assert.Equal(t, recv(), HealthCheckResponse_SERVING)
+
+	srv.SetServingStatus("svc", HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, recv(), HealthCheckResponse_NOT_SERVING)
+}
+
+func serveConn(t *testing.T, ctx *drpctest.Tracker, srv *Server) *drpcconn.Conn {
+	mux := drpcmux.New()
+	assert.NoError(t, DRPCRegisterHealth(mux, srv))
+
+	c1, c2 := net.Pipe()
+	ctx.Run(func(ctx context.Context) { _ = drpcserver.New(mux).ServeOne(ctx, c1) })
+	return drpcconn.New(c2)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchealth
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc/drpcerr"
+)
+
+// Server implements the health service by keeping the serving status of every
+// service. It starts with the empty service, which represents the server as a
+// whole, in the SERVING status.
+type Server struct {
+	DRPCHealthUnimplementedServer
+
+	mu       sync.Mutex
+	shutdown bool
+	statuses map[string]HealthCheckResponse_ServingStatus
+	watchers map[string]map[chan HealthCheckResponse_ServingStatus]struct{}
+}
+
+var _ DRPCHealthServer = (*Server)(nil)
+
+// NewServer constructs a new Server.
+func NewServer() *Server {
+	return &Server{
+		statuses: map[string]HealthCheckResponse_ServingStatus{
+			"": HealthCheckResponse_SERVING,
+		},
+		watchers: make(map[string]map[chan HealthCheckResponse_ServingStatus]struct{}),
+	}
+}
+
+// SetServingStatus sets the serving status of the service and notifies any
+// watchers of it. It is ignored after Shutdown until Resume is called.
+func (s *Server) SetServingStatus(service string, status HealthCheckResponse_ServingStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.shutdown {
+		s.setLocked(service, status)
+	}
+}
+
+// Shutdown sets the status of every service to NOT_SERVING and ignores any
+// further updates until Resume is called. It is meant to be called when the
+// server starts to shut down so that clients stop sending it rpcs.
+func (s *Server) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shutdown = true
+	for service := range s.statuses {
+		s.setLocked(service, HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Resume sets the status of every service to SERVING and allows further
+// updates with SetServingStatus.
+func (s *Server) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shutdown = false
+	for service := range s.statuses {
+		s.setLocked(service, HealthCheckResponse_SERVING)
+	}
+}
+
+// setLocked sets the status of the service and notifies its watchers. It must
+// be called with the mutex held.
+func (s *Server) setLocked(service string, status HealthCheckResponse_ServingStatus) {
+	s.statuses[service] = status
+	for ch := range s.watchers[service] {
+		notify(ch, status)
+	}
+}
+
+// notify replaces any status the watcher has not received yet with the new
+// status so that slow watchers always see the latest one.
+func notify(ch chan HealthCheckResponse_ServingStatus, status HealthCheckResponse_ServingStatus) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- status
+}
+
+// Check returns the serving status of the requested service or an error with
+// the drpcerr.NotFound code if the service is unknown.
+func (s *Server) Check(ctx context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, ok := s.statuses[req.GetService()]
+	if !ok {
+		return nil, drpcerr.WithCode(errs.New("unknown service: %q", req.GetService()), drpcerr.NotFound)
+	}
+	return &HealthCheckResponse{Status: status}, nil
+}
+
+// Watch sends the serving status of the requested service, or SERVICE_UNKNOWN
+// if it is unknown, and then sends every change to it until the stream is
+// canceled.
+func (s *Server) Watch(req *HealthCheckRequest, stream DRPCHealth_WatchStream) error {
+	service := req.GetService()
+	ch := make(chan HealthCheckResponse_ServingStatus, 1)
+
+	s.mu.Lock()
+	status, ok := s.statuses[service]
+	if !ok {
+		status = HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	ch <- status
+	if s.watchers[service] == nil {
+		s.watchers[service] = make(map[chan HealthCheckResponse_ServingStatus]struct{})
+	}
+	s.watchers[service][ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.watchers[service], ch)
+		if len(s.watchers[service]) == 0 {
+			delete(s.watchers, service)
+		}
+	}()
+
+	last := HealthCheckResponse_ServingStatus(-1)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case status := <-ch:
+			if status == last {
+				continue
+			}
+			last = status
+			if err := stream.Send(&HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchttp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//
+// code to set request message fields from path and query parameters
+//
+
+var errUnknownField = errs.Class("unknown field")
+
+// findField returns the field of the message with the name or JSON name, or
+// nil if there is none.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return md.Fields().ByJSONName(name)
+}
+
+// setField sets the field at the dot separated path in the message from the
+// values. Only repeated fields may have more than one value.
+func setField(m protoreflect.Message, path string, values []string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := findField(m.Descriptor(), name)
+		switch {
+		case fd == nil:
+			return errUnknownField.New("%q", strings.Join(names[:i+1], "."))
+
+		case i < len(names)-1:
+			if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+				return errs.New("%q is not a message", strings.Join(names[:i+1], "."))
+			}
+			m = m.Mutable(fd).Message()
+
+		case fd.IsMap():
+			return errs.New("%q is a map", path)
+
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for _, value := range values {
+				v, err := parseValue(fd, list.NewElement(), value)
+				if err != nil {
+					return err
+				}
+				list.Append(v)
+			}
+
+		case len(values) != 1:
+			return errs.New("%q is not repeated", path)
+
+		default:
+			v, err := parseValue(fd, m.NewField(fd), values[0])
+			if err != nil {
+				return err
+			}
+			m.Set(fd, v)
+		}
+	}
+	return nil
+}
+
+// parseValue parses the string as a value of the field. The value v is a new
+// value of the field, filled in when the field is a message.
+func parseValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, s string) (protoreflect.Value, error) {
+	var err error
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		var x bool
+		x, err = strconv.ParseBool(s)
+		v = protoreflect.ValueOfBool(x)
+
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		var x int64
+		x, err = strconv.ParseInt(s, 10, 32)
+		v = protoreflect.ValueOfEnum(protoreflect.EnumNumber(x))
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var x int64
+		x, err = strconv.ParseInt(s, 10, 32)
+		v = protoreflect.ValueOfInt32(int32(x))
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var x int64
+		x, err = strconv.ParseInt(s, 10, 64)
+		v = protoreflect.ValueOfInt64(x)
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var x uint64
+		x, err = strconv.ParseUint(s, 10, 32)
+		v = protoreflect.ValueOfUint32(uint32(x))
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var x uint64
+		x, err = strconv.ParseUint(s, 10, 64)
+		v = protoreflect.ValueOfUint64(x)
+
+	case protoreflect.FloatKind:
+		var x float64
+		x, err = strconv.ParseFloat(s, 32)
+		v = protoreflect.ValueOfFloat32(float32(x))
+
+	case protoreflect.DoubleKind:
+		var x float64
+		x, err = strconv.ParseFloat(s, 64)
+		v = protoreflect.ValueOfFloat64(x)
+
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(s)
+
+	case protoreflect.BytesKind:
+		var x []byte
+		x, err = decodeBytes(s)
+		v = protoreflect.ValueOfBytes(x)
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// well known types like timestamps and wrappers have JSON forms that
+		// are scalars, so parse the value as JSON if it is valid, and as a JSON
+		// string otherwise.
+		data := []byte(s)
+		if !json.Valid(data) {
+			data = []byte(strconv.Quote(s))
+		}
+		err = protojson.Unmarshal(data, v.Message().Interface())
+
+	default:
+		err = errs.New("unsupported kind %v", fd.Kind())
+	}
+	if err != nil {
+		return protoreflect.Value{}, errs.New("invalid value %q: %v", s, err)
+	}
+	return v, nil
+}
+
+// decodeBytes decodes standard or URL safe base64 with optional padding, like
+// the JSON encoding of bytes fields.
+func decodeBytes(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "-_") {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+// zeroJSON returns the JSON encoding of the zero value of the field, which is
+// omitted when encoding the message that contains it.
+func zeroJSON(fd protoreflect.FieldDescriptor) []byte {
+	switch {
+	case fd.IsList():
+		return []byte("[]")
+	case fd.IsMap():
+		return []byte("{}")
+	}
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return []byte("null")
+	case protoreflect.BoolKind:
+		return []byte("false")
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return []byte(`""`)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(0); ev != nil {
+			return []byte(strconv.Quote(string(ev.Name())))
+		}
+		return []byte("0")
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return []byte(`"0"`)
+	default:
+		return []byte("0")
+	}
+}
@@ -54,6 +54,8 @@ func New(handler drpc.Handler) http.Handler {
 // are the message length in big endian. Response codes and status messages
 // are sent as HTTP Trailers. The "-text" series of content types mean that
 // the whole request and response bodies are base64 encoded.
+//
+// RPCs can also be served at other paths with the WithRoutes option.
 func NewWithOptions(handler drpc.Handler, os ...Option) http.Handler {
 	opts := options{protocols: defaultProtocols()}
 	for _, o := range os {
@@ -72,10 +74,17 @@ type wrapper struct {
 }
 
 func (w wrapper) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	rpc := req.URL.Path
 	pr, ok := w.opts.protocols[req.Header.Get("Content-Type")]
 	if !ok {
 		pr = w.opts.protocols["*"]
 	}
+	for _, rt := range w.opts.routes {
+		if rpr, ok := rt.match(req); ok {
+			rpc, pr = rt.RPC, rpr
+			break
+		}
+	}
 
 	ctx, err := Context(req)
 	if err == nil {
@@ -83,7 +92,7 @@ func (w wrapper) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	}
 
 	st := pr.NewStream(rw, req)
-	st.Finish(w.handler.HandleRPC(st, req.URL.Path))
+	st.Finish(w.handler.HandleRPC(st, rpc))
 }
 
 // getCode returns a string code for the provided error, or "unknown" if it
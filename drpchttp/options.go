@@ -14,6 +14,7 @@ type Option struct{ apply func(*options) }
 
 type options struct {
 	protocols map[string]Protocol
+	routes    []*route
 }
 
 // Protocol is used by the handler to create drpc.Streams from incoming
@@ -44,6 +45,29 @@ func WithProtocol(contentType string, pr Protocol) Option {
 	}}
 }
 
+// WithRoutes adds routes that serve RPCs at the paths and with the HTTP methods
+// of google.api.http style annotations, as generated by protoc-gen-go-drpc.
+// Requests are matched against the routes in order before falling back to the
+// path based on the RPC name. It panics if any route is invalid.
+//
+// Requests matched by a route always use JSON bodies. Path variables and query
+// parameters are bound to the request message fields they name, which
+// requires the messages to implement google.golang.org/protobuf/proto.Message.
+// Query parameters that do not name a field are ignored, and are not bound at
+// all if the whole request message is decoded from the body. Errors are
+// reported the same way as for the "application/json" content type.
+func WithRoutes(routes ...Route) Option {
+	return Option{apply: func(opts *options) {
+		for _, r := range routes {
+			rt, err := r.compile()
+			if err != nil {
+				panic(err)
+			}
+			opts.routes = append(opts.routes, rt)
+		}
+	}}
+}
+
 func defaultProtocols() map[string]Protocol {
 	return map[string]Protocol{
 		"*": twirpProtocol{
@@ -0,0 +1,199 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/proto"
+
+	"storj.io/drpc"
+)
+
+// Route maps HTTP requests with a method and a path matching a template to an
+// RPC, like a google.api.http annotation. protoc-gen-go-drpc generates the
+// routes for annotated services in a DRPC<Service>Routes function.
+type Route struct {
+	// Method is the HTTP method of the requests, like "GET".
+	Method string
+
+	// Pattern is the path template of the requests, like
+	// "/v1/{name=shelves/*}/books". Variables in it are bound to the request
+	// message fields they name.
+	Pattern string
+
+	// RPC is the name of the RPC to invoke, like "/package.Service/Method".
+	RPC string
+
+	// Body is the request message field the JSON request body is decoded into.
+	// If it is "*", the body is decoded into the whole request message. If it
+	// is empty, the request has no body.
+	Body string
+
+	// ResponseBody is the response message field that is encoded as the JSON
+	// response body. If it is empty, the whole response message is encoded.
+	ResponseBody string
+}
+
+// Validate returns an error if the route is invalid.
+func (r Route) Validate() error {
+	_, err := r.compile()
+	return err
+}
+
+// compile validates the route and parses its pattern.
+func (r Route) compile() (*route, error) {
+	if r.Method == "" {
+		return nil, errs.New("route for %q has no method", r.RPC)
+	} else if r.RPC == "" {
+		return nil, errs.New("route for %q has no rpc", r.Pattern)
+	} else if r.Body != "*" && r.Body != "" && !isIdent(r.Body) {
+		return nil, errs.New("route for %q has invalid body %q", r.RPC, r.Body)
+	} else if r.ResponseBody != "" && !isIdent(r.ResponseBody) {
+		return nil, errs.New("route for %q has invalid response body %q", r.RPC, r.ResponseBody)
+	}
+	t, err := parseTemplate(r.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &route{Route: r, tmpl: t}, nil
+}
+
+// isIdent returns true if s is a valid top-level field name.
+func isIdent(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' || !isFieldPathByte(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// route is a compiled Route.
+type route struct {
+	Route
+	tmpl *template
+}
+
+// match returns a Protocol that serves the request with the route if it
+// matches.
+func (r *route) match(req *http.Request) (Protocol, bool) {
+	if req.Method != r.Method {
+		return nil, false
+	}
+	bindings, ok := r.tmpl.match(req.URL.EscapedPath())
+	if !ok {
+		return nil, false
+	}
+	return routeProtocol{route: r, bindings: bindings}, true
+}
+
+//
+// protocol handler
+//
+
+// routeProtocol serves a request matched by a route with JSON bodies and the
+// same error responses as the Twirp style protocols.
+type routeProtocol struct {
+	route    *route
+	bindings []binding
+}
+
+func (rp routeProtocol) NewStream(rw http.ResponseWriter, req *http.Request) Stream {
+	return twirpProtocol{
+		ct:        "application/json",
+		marshal:   rp.marshal,
+		unmarshal: func(buf []byte, msg drpc.Message, enc drpc.Encoding) error { return rp.unmarshal(req, buf, msg, enc) },
+	}.NewStream(rw, req)
+}
+
+// unmarshal decodes the body into the selected request field and then sets the
+// fields bound by the path and query parameters.
+func (rp routeProtocol) unmarshal(req *http.Request, buf []byte, msg drpc.Message, enc drpc.Encoding) error {
+	body := rp.route.Body
+	if body != "" && len(bytes.TrimSpace(buf)) > 0 {
+		if body != "*" {
+			buf = append(append([]byte("{"+strconv.Quote(body)+":"), buf...), '}')
+		}
+		if err := JSONUnmarshal(buf, msg, enc); err != nil {
+			return badRequest(errs.New("invalid body: %v", err))
+		}
+	}
+
+	query := req.URL.Query()
+	if body == "*" {
+		query = nil
+	}
+	if len(rp.bindings) == 0 && len(query) == 0 {
+		return nil
+	}
+
+	pmsg, ok := msg.(proto.Message)
+	if !ok {
+		return errs.New("cannot bind parameters to %T", msg)
+	}
+	m := pmsg.ProtoReflect()
+
+	bound := make(map[string]bool, len(rp.bindings))
+	for _, b := range rp.bindings {
+		if err := setField(m, b.field, []string{b.value}); err != nil {
+			return badRequest(errs.New("path parameter %q: %v", b.field, err))
+		}
+		bound[b.field] = true
+	}
+
+	for key, values := range query {
+		if bound[key] || (body != "" && (key == body || strings.HasPrefix(key, body+"."))) {
+			continue
+		}
+		if err := setField(m, key, values); errUnknownField.Has(err) {
+			continue
+		} else if err != nil {
+			return badRequest(errs.New("query parameter %q: %v", key, err))
+		}
+	}
+
+	return nil
+}
+
+// marshal encodes the selected response field.
+func (rp routeProtocol) marshal(msg drpc.Message, enc drpc.Encoding) ([]byte, error) {
+	data, err := JSONMarshal(msg, enc)
+	if err != nil || rp.route.ResponseBody == "" {
+		return data, err
+	}
+
+	pmsg, ok := msg.(proto.Message)
+	if !ok {
+		return nil, errs.New("cannot select response field from %T", msg)
+	}
+	fd := findField(pmsg.ProtoReflect().Descriptor(), rp.route.ResponseBody)
+	if fd == nil {
+		return nil, errs.New("unknown response field %q", rp.route.ResponseBody)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{fd.JSONName(), string(fd.Name())} {
+		if value, ok := fields[name]; ok {
+			return value, nil
+		}
+	}
+	return zeroJSON(fd), nil
+}
+
+// badRequest wraps the error so that it is reported with a 400 status.
+func badRequest(err error) error { return invalidArgument{err} }
+
+type invalidArgument struct{ error }
+
+func (e invalidArgument) Code() string  { return "invalid_argument" }
+func (e invalidArgument) Unwrap() error { return e.error }
@@ -0,0 +1,197 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc/drpchealth"
+	"storj.io/drpc/drpcmux"
+)
+
+func TestTemplate(t *testing.T) {
+	type match struct {
+		path     string
+		bindings []binding
+	}
+
+	for _, tc := range []struct {
+		pattern string
+		matches []match
+		misses  []string
+	}{
+		{
+			pattern: "/v1/shelves",
+			matches: []match{{path: "/v1/shelves", bindings: []binding{}}},
+			misses:  []string{"/v1", "/v1/shelves/", "/v1/shelves/1", "/v2/shelves"},
+		},
+		{
+			pattern: "/v1/shelves/{shelf}/books/{book.id}",
+			matches: []match{
+				{path: "/v1/shelves/1/books/a%2Fb", bindings: []binding{{"shelf", "1"}, {"book.id", "a/b"}}},
+			},
+			misses: []string{"/v1/shelves//books/1", "/v1/shelves/1/books"},
+		},
+		{
+			pattern: "/v1/{name=shelves/*/books/*}",
+			matches: []match{
+				{path: "/v1/shelves/1/books/2", bindings: []binding{{"name", "shelves/1/books/2"}}},
+			},
+			misses: []string{"/v1/shelves/1/books", "/v1/shelves/1/magazines/2"},
+		},
+		{
+			pattern: "/v1/{path=files/**}",
+			matches: []match{
+				{path: "/v1/files/a/b/c", bindings: []binding{{"path", "files/a/b/c"}}},
+				{path: "/v1/files", bindings: []binding{{"path", "files"}}},
+			},
+			misses: []string{"/v1/dirs/a"},
+		},
+		{
+			pattern: "/v1/{name=*}:cancel",
+			matches: []match{{path: "/v1/op:cancel", bindings: []binding{{"name", "op"}}}},
+			misses:  []string{"/v1/op", "/v1/op:delete"},
+		},
+	} {
+		tmpl, err := parseTemplate(tc.pattern)
+		assert.NoError(t, err)
+		for _, m := range tc.matches {
+			bindings, ok := tmpl.match(m.path)
+			assert.That(t, ok)
+			assert.DeepEqual(t, bindings, m.bindings)
+		}
+		for _, path := range tc.misses {
+			_, ok := tmpl.match(path)
+			assert.That(t, !ok)
+		}
+	}
+
+	for _, pattern := range []string{
+		"", "v1", "/v1/", "/v1//a", "/v1/{name", "/v1/{}", "/v1/{a..b}",
+		"/v1/{a={b}}", "/v1/**/a", "/v1/a:", "/v1/a}",
+	} {
+		_, err := parseTemplate(pattern)
+		assert.Error(t, err)
+	}
+}
+
+func TestSetField(t *testing.T) {
+	msg := new(descriptorpb.FieldDescriptorProto)
+	m := msg.ProtoReflect()
+
+	assert.NoError(t, setField(m, "name", []string{"field"}))
+	assert.NoError(t, setField(m, "number", []string{"5"}))
+	assert.NoError(t, setField(m, "label", []string{"LABEL_REPEATED"}))
+	assert.NoError(t, setField(m, "type", []string{"9"}))
+	assert.NoError(t, setField(m, "jsonName", []string{"json"}))
+	assert.NoError(t, setField(m, "options.deprecated", []string{"true"}))
+	assert.That(t, proto.Equal(msg, &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("field"),
+		Number:   proto.Int32(5),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		JsonName: proto.String("json"),
+		Options:  &descriptorpb.FieldOptions{Deprecated: proto.Bool(true)},
+	}))
+
+	assert.That(t, errUnknownField.Has(setField(m, "unknown", []string{"x"})))
+	assert.That(t, errUnknownField.Has(setField(m, "options.unknown", []string{"x"})))
+	assert.Error(t, setField(m, "number", []string{"five"}))
+	assert.Error(t, setField(m, "number", []string{"1", "2"}))
+	assert.Error(t, setField(m, "name.value", []string{"x"}))
+
+	file := new(descriptorpb.FileDescriptorProto)
+	assert.NoError(t, setField(file.ProtoReflect(), "dependency", []string{"a.proto", "b.proto"}))
+	assert.NoError(t, setField(file.ProtoReflect(), "public_dependency", []string{"1"}))
+	assert.DeepEqual(t, file.Dependency, []string{"a.proto", "b.proto"})
+	assert.DeepEqual(t, file.PublicDependency, []int32{1})
+}
+
+func TestRoutes(t *testing.T) {
+	health := drpchealth.NewServer()
+	health.SetServingStatus("svc", drpchealth.HealthCheckResponse_NOT_SERVING)
+
+	mux := drpcmux.New()
+	assert.NoError(t, drpchealth.DRPCRegisterHealth(mux, health))
+
+	server := httptest.NewServer(NewWithOptions(mux, WithRoutes(
+		Route{Method: "GET", Pattern: "/v1/health/{service}", RPC: "/drpc.health.v1.Health/Check"},
+		Route{Method: "GET", Pattern: "/v1/status", RPC: "/drpc.health.v1.Health/Check", ResponseBody: "status"},
+		Route{Method: "POST", Pattern: "/v1/health:check", RPC: "/drpc.health.v1.Health/Check", Body: "*"},
+		Route{Method: "PUT", Pattern: "/v1/health", RPC: "/drpc.health.v1.Health/Check", Body: "service"},
+	)))
+	defer server.Close()
+
+	request := func(method, path, body string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		assert.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		if json.Compact(&buf, data) != nil {
+			return resp.StatusCode, string(data)
+		}
+		return resp.StatusCode, buf.String()
+	}
+
+	// path variables, query parameters and bodies are bound to fields.
+	status, body := request("GET", "/v1/health/svc", "")
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, body, `{"status":"NOT_SERVING"}`)
+
+	status, body = request("GET", "/v1/status?service=svc&ignored=1", "")
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, body, `"NOT_SERVING"`)
+
+	status, body = request("POST", "/v1/health:check?service=ignored", `{"service": "svc"}`)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, body, `{"status":"NOT_SERVING"}`)
+
+	status, body = request("PUT", "/v1/health", `"svc"`)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, body, `{"status":"NOT_SERVING"}`)
+
+	// the zero value of a selected response field is still encoded.
+	status, body = request("GET", "/v1/status", "")
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, body, `"SERVING"`)
+
+	// errors are reported like the json protocol.
+	status, body = request("GET", "/v1/health/unknown", "")
+	assert.Equal(t, status, http.StatusInternalServerError)
+	assert.Equal(t, body, `{"code":"drpcerr(5)","msg":"unknown service: \"unknown\""}`)
+
+	status, body = request("POST", "/v1/health:check", `{`)
+	assert.Equal(t, status, http.StatusBadRequest)
+	assert.That(t, strings.Contains(body, `"code":"invalid_argument"`))
+
+	// unmatched requests fall back to the rpc name.
+	status, _ = request("POST", "/drpc.health.v1.Health/Check", "")
+	assert.Equal(t, status, http.StatusOK)
+}
+
+func TestRouteValidate(t *testing.T) {
+	assert.NoError(t, Route{Method: "GET", Pattern: "/v1/{name}", RPC: "/s/m"}.Validate())
+	assert.Error(t, Route{Pattern: "/v1/{name}", RPC: "/s/m"}.Validate())
+	assert.Error(t, Route{Method: "GET", Pattern: "/v1/{name}"}.Validate())
+	assert.Error(t, Route{Method: "GET", Pattern: "/v1/{name", RPC: "/s/m"}.Validate())
+	assert.Error(t, Route{Method: "GET", Pattern: "/v1", RPC: "/s/m", Body: "a.b"}.Validate())
+	assert.Error(t, Route{Method: "GET", Pattern: "/v1", RPC: "/s/m", ResponseBody: "*"}.Validate())
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpchttp
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+//
+// path templates in the syntax of google.api.http annotations
+//
+//	Template = "/" Segments [ Verb ] ;
+//	Segments = Segment { "/" Segment } ;
+//	Segment  = "*" | "**" | LITERAL | Variable ;
+//	Variable = "{" FieldPath [ "=" Segments ] "}" ;
+//	FieldPath = IDENT { "." IDENT } ;
+//	Verb     = ":" LITERAL ;
+//
+
+// segment is a single component of a template's path.
+type segment struct {
+	lit  string // the literal to match if neither wild nor deep
+	wild bool   // matches any single segment
+	deep bool   // matches any number of segments
+}
+
+// variable binds the path segments in [start, end) to a field.
+type variable struct {
+	field      string
+	start, end int
+}
+
+// template is a parsed path template.
+type template struct {
+	segs []segment
+	vars []variable
+	verb string
+}
+
+// binding is the value of a variable in a matched path.
+type binding struct {
+	field string
+	value string
+}
+
+// parseTemplate parses the path template.
+func parseTemplate(s string) (*template, error) {
+	p := &templateParser{s: s}
+	t, err := p.parse()
+	if err != nil {
+		return nil, errs.New("invalid path template %q: %v", s, err)
+	}
+	return t, nil
+}
+
+// templateParser holds the state of parsing a path template.
+type templateParser struct {
+	s string
+	i int
+	t template
+}
+
+func (p *templateParser) peek() byte {
+	if p.i < len(p.s) {
+		return p.s[p.i]
+	}
+	return 0
+}
+
+func (p *templateParser) consume(c byte) bool {
+	if p.peek() == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *templateParser) parse() (*template, error) {
+	if !p.consume('/') {
+		return nil, errs.New("must start with /")
+	}
+	if err := p.parseSegments(false); err != nil {
+		return nil, err
+	}
+	if p.consume(':') {
+		p.t.verb = p.parseLiteral()
+		if p.t.verb == "" {
+			return nil, errs.New("empty verb")
+		}
+	}
+	if p.i < len(p.s) {
+		return nil, errs.New("unexpected %q at offset %d", p.s[p.i], p.i)
+	}
+	for i, seg := range p.t.segs {
+		if seg.deep && i != len(p.t.segs)-1 {
+			return nil, errs.New("** must be the last segment")
+		}
+	}
+	return &p.t, nil
+}
+
+func (p *templateParser) parseSegments(inVar bool) error {
+	for {
+		if err := p.parseSegment(inVar); err != nil {
+			return err
+		}
+		if !p.consume('/') {
+			return nil
+		}
+	}
+}
+
+func (p *templateParser) parseSegment(inVar bool) error {
+	switch {
+	case p.consume('*'):
+		if p.consume('*') {
+			p.t.segs = append(p.t.segs, segment{deep: true})
+		} else {
+			p.t.segs = append(p.t.segs, segment{wild: true})
+		}
+
+	case p.consume('{'):
+		if inVar {
+			return errs.New("nested variable at offset %d", p.i-1)
+		}
+		return p.parseVariable()
+
+	default:
+		lit := p.parseLiteral()
+		if lit == "" {
+			return errs.New("empty segment at offset %d", p.i)
+		}
+		p.t.segs = append(p.t.segs, segment{lit: lit})
+	}
+	return nil
+}
+
+func (p *templateParser) parseVariable() error {
+	start := p.i
+	for p.i < len(p.s) && isFieldPathByte(p.s[p.i]) {
+		p.i++
+	}
+	field := p.s[start:p.i]
+	if field == "" || strings.HasPrefix(field, ".") ||
+		strings.HasSuffix(field, ".") || strings.Contains(field, "..") {
+		return errs.New("invalid field path %q", field)
+	}
+
+	v := variable{field: field, start: len(p.t.segs)}
+	if p.consume('=') {
+		if err := p.parseSegments(true); err != nil {
+			return err
+		}
+	} else {
+		p.t.segs = append(p.t.segs, segment{wild: true})
+	}
+	v.end = len(p.t.segs)
+
+	if !p.consume('}') {
+		return errs.New("unterminated variable %q", field)
+	}
+	p.t.vars = append(p.t.vars, v)
+	return nil
+}
+
+func (p *templateParser) parseLiteral() string {
+	start := p.i
+	for p.i < len(p.s) && !strings.ContainsRune("/{}*=:", rune(p.s[p.i])) {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+func isFieldPathByte(c byte) bool {
+	return c == '_' || c == '.' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// match returns the values of the variables if the escaped path matches the
+// template.
+func (t *template) match(path string) ([]binding, bool) {
+	if t.verb != "" {
+		if !strings.HasSuffix(path, ":"+t.verb) {
+			return nil, false
+		}
+		path = path[:len(path)-len(t.verb)-1]
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+
+	parts := strings.Split(path[1:], "/")
+	for i, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, false
+		}
+		parts[i] = unescaped
+	}
+
+	// ends maps the end of each template segment to the end in parts, which
+	// only differ for a trailing deep segment.
+	ends := make([]int, len(t.segs)+1)
+	for i, seg := range t.segs {
+		switch {
+		case seg.deep:
+			ends[i+1] = len(parts)
+		case i >= len(parts):
+			return nil, false
+		case seg.wild:
+			if parts[i] == "" {
+				return nil, false
+			}
+			ends[i+1] = i + 1
+		case seg.lit != parts[i]:
+			return nil, false
+		default:
+			ends[i+1] = i + 1
+		}
+	}
+	if ends[len(t.segs)] != len(parts) {
+		return nil, false
+	}
+
+	bindings := make([]binding, 0, len(t.vars))
+	for _, v := range t.vars {
+		bindings = append(bindings, binding{
+			field: v.field,
+			value: strings.Join(parts[ends[v.start]:ends[v.end]], "/"),
+		})
+	}
+	return bindings, true
+}
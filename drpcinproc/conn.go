@@ -0,0 +1,177 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcinproc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/proto"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpccache"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// Options controls configuration settings for a Conn.
+type Options struct {
+	// Clone causes messages to be copied with Copy instead of being marshaled
+	// and unmarshaled with the encoding of the rpc. Messages must be pointers.
+	Clone bool
+
+	// Copy deep copies src into dst, which is a new message of the same type,
+	// when Clone is set. If nil, google.golang.org/protobuf is used, which
+	// requires the messages to implement its proto.Message.
+	Copy func(dst, src drpc.Message) error
+}
+
+// Conn is a drpc.Conn that dispatches rpcs to a handler in the same process.
+// Unlike connections over a transport, any number of Invoke and NewStream
+// calls may be active at once.
+type Conn struct {
+	handler drpc.Handler
+	opts    Options
+	cache   *drpccache.Cache
+
+	mu      sync.Mutex
+	streams map[*stream]struct{}
+	closed  bool
+	done    chan struct{}
+}
+
+var _ drpc.Conn = (*Conn)(nil)
+
+// New returns a Conn that dispatches rpcs to the handler.
+func New(handler drpc.Handler) *Conn {
+	return NewWithOptions(handler, Options{})
+}
+
+// NewWithOptions returns a Conn that dispatches rpcs to the handler using the
+// provided options.
+func NewWithOptions(handler drpc.Handler, opts Options) *Conn {
+	if opts.Copy == nil {
+		opts.Copy = copyProto
+	}
+	return &Conn{
+		handler: handler,
+		opts:    opts,
+		cache:   drpccache.New(),
+		streams: make(map[*stream]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// copyProto deep copies src into dst with google.golang.org/protobuf.
+func copyProto(dst, src drpc.Message) error {
+	pdst, ok1 := dst.(proto.Message)
+	psrc, ok2 := src.(proto.Message)
+	if !ok1 || !ok2 {
+		return errs.New("cannot clone non-protobuf message %T without a Copy function", src)
+	}
+	proto.Reset(pdst)
+	proto.Merge(pdst, psrc)
+	return nil
+}
+
+// Closed returns a channel that is closed once the Conn is closed.
+func (c *Conn) Closed() <-chan struct{} { return c.done }
+
+// Close closes the Conn, terminating any active streams and canceling the
+// contexts of their handlers.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	streams := c.streams
+	c.streams = nil
+	c.mu.Unlock()
+
+	for st := range streams {
+		err := drpc.ClosedError.New("connection closed")
+		st.terminate(err, err)
+	}
+	c.cache.Clear()
+	return nil
+}
+
+// Invoke calls the rpc with in and copies the response into out. The handler
+// runs in the calling goroutine.
+func (c *Conn) Invoke(ctx context.Context, rpc string, enc drpc.Encoding, in, out drpc.Message) (err error) {
+	st, err := c.newStream(ctx, rpc)
+	if err != nil {
+		return err
+	}
+	client := st.client()
+	defer func() { err = errs.Combine(err, client.Close()) }()
+
+	if err := client.MsgSend(in, enc); err != nil {
+		return err
+	}
+	if err := client.CloseSend(); err != nil {
+		return err
+	}
+	st.serve()
+	return client.MsgRecv(out, enc)
+}
+
+// NewStream begins a streaming rpc. The handler runs in its own goroutine
+// until it returns.
+func (c *Conn) NewStream(ctx context.Context, rpc string, enc drpc.Encoding) (drpc.Stream, error) {
+	st, err := c.newStream(ctx, rpc)
+	if err != nil {
+		return nil, err
+	}
+	go st.serve()
+	return st.client(), nil
+}
+
+// newStream creates a stream for the rpc with a server side context carrying
+// the metadata from the client side context.
+func (c *Conn) newStream(ctx context.Context, rpc string) (*stream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sctx := drpccache.WithContext(context.Background(), c.cache)
+	if md, ok := drpcmetadata.Get(ctx); ok {
+		// round trip the metadata so that it is validated and copied like it
+		// would be over a transport.
+		buf, err := drpcmetadata.Encode(nil, md)
+		if err != nil {
+			return nil, err
+		}
+		md, err = drpcmetadata.Decode(buf)
+		if err != nil {
+			return nil, err
+		}
+		sctx = drpcmetadata.AddPairs(sctx, md)
+	}
+
+	st := newStream(c, ctx, sctx, rpc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		st.stop()
+		st.ccancel()
+		st.scancel()
+		return nil, drpc.ClosedError.New("connection closed")
+	}
+	c.streams[st] = struct{}{}
+	return st, nil
+}
+
+// remove forgets about the finished stream.
+func (c *Conn) remove(st *stream) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.streams, st)
+}
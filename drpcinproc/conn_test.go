@@ -0,0 +1,158 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcinproc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpccache"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpchealth"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcmux"
+)
+
+func TestInvoke(t *testing.T) {
+	for _, opts := range []Options{{}, {Clone: true}} {
+		health := drpchealth.NewServer()
+		mux := drpcmux.New()
+		assert.NoError(t, drpchealth.DRPCRegisterHealth(mux, health))
+
+		conn := NewWithOptions(mux, opts)
+		ctx := context.Background()
+
+		assert.NoError(t, drpchealth.Check(ctx, conn, ""))
+
+		// errors keep their code and message, but not their identity.
+		err := drpchealth.Check(ctx, conn, "unknown")
+		assert.Equal(t, drpcerr.Code(err), drpcerr.NotFound)
+		assert.Equal(t, err.Error(), `unknown service: "unknown"`)
+
+		assert.NoError(t, conn.Close())
+		assert.That(t, drpc.ClosedError.Has(drpchealth.Check(ctx, conn, "")))
+	}
+}
+
+func TestStream(t *testing.T) {
+	health := drpchealth.NewServer()
+	mux := drpcmux.New()
+	assert.NoError(t, drpchealth.DRPCRegisterHealth(mux, health))
+
+	conn := NewWithOptions(mux, Options{Clone: true})
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := drpchealth.NewDRPCHealthClient(conn).Watch(ctx, &drpchealth.HealthCheckRequest{Service: "svc"})
+	assert.NoError(t, err)
+
+	resp, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, resp.GetStatus(), drpchealth.HealthCheckResponse_SERVICE_UNKNOWN)
+
+	health.SetServingStatus("svc", drpchealth.HealthCheckResponse_SERVING)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, resp.GetStatus(), drpchealth.HealthCheckResponse_SERVING)
+
+	// canceling the context ends the stream and the handler.
+	cancel()
+	_, err = stream.Recv()
+	assert.That(t, errors.Is(err, context.Canceled))
+	<-stream.Context().Done()
+}
+
+func TestContext(t *testing.T) {
+	type key struct{}
+
+	ctxs := make(chan context.Context, 1)
+	conn := New(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		ctxs <- stream.Context()
+		var in string
+		if err := stream.MsgRecv(&in, stringEncoding{}); err != nil {
+			return err
+		}
+		return stream.MsgSend(&in, stringEncoding{})
+	}))
+	defer func() { _ = conn.Close() }()
+
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	ctx = drpcmetadata.Add(ctx, "key", "metadata")
+
+	in, out := "hello", ""
+	assert.NoError(t, conn.Invoke(ctx, "rpc", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "hello")
+
+	// errors from the mux are returned like they are by a server.
+	mux := drpcmux.New()
+	err := NewWithOptions(mux, Options{}).Invoke(ctx, "unknown", stringEncoding{}, &in, &out)
+	assert.That(t, err != nil && err.Error() == `protocol error: unknown rpc: "unknown"`)
+
+	// the handler sees the metadata and cache but not the caller's values,
+	// and its context is canceled once the rpc is done.
+	sctx := <-ctxs
+	md, ok := drpcmetadata.Get(sctx)
+	assert.That(t, ok)
+	assert.Equal(t, md["key"], "metadata")
+	assert.Nil(t, sctx.Value(key{}))
+	assert.NotNil(t, drpccache.FromContext(sctx))
+	assert.Error(t, sctx.Err())
+}
+
+func TestClose(t *testing.T) {
+	recvd := make(chan error, 1)
+	conn := New(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		var in string
+		for {
+			if err := stream.MsgRecv(&in, stringEncoding{}); err != nil {
+				recvd <- err
+				return nil
+			}
+		}
+	}))
+	defer func() { _ = conn.Close() }()
+
+	ctx := context.Background()
+	stream, err := conn.NewStream(ctx, "rpc", stringEncoding{})
+	assert.NoError(t, err)
+
+	// closing the stream gives the handler io.EOF and fails further use.
+	in := "hello"
+	assert.NoError(t, stream.MsgSend(&in, stringEncoding{}))
+	assert.NoError(t, stream.Close())
+	assert.That(t, errors.Is(<-recvd, io.EOF))
+	assert.Error(t, stream.MsgSend(&in, stringEncoding{}))
+
+	// closing the conn terminates the active streams.
+	stream, err = conn.NewStream(ctx, "rpc", stringEncoding{})
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.That(t, drpc.ClosedError.Has(<-recvd))
+	assert.That(t, drpc.ClosedError.Has(stream.MsgSend(&in, stringEncoding{})))
+
+	_, err = conn.NewStream(ctx, "rpc", stringEncoding{})
+	assert.That(t, drpc.ClosedError.Has(err))
+}
+
+type handlerFunc func(stream drpc.Stream, rpc string) error
+
+func (fn handlerFunc) HandleRPC(stream drpc.Stream, rpc string) error { return fn(stream, rpc) }
+
+type stringEncoding struct{}
+
+func (stringEncoding) Marshal(msg drpc.Message) ([]byte, error) {
+	return []byte(*msg.(*string)), nil
+}
+
+func (stringEncoding) Unmarshal(buf []byte, msg drpc.Message) error {
+	*msg.(*string) = string(buf)
+	return nil
+}
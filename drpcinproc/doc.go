@@ -0,0 +1,25 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcinproc provides a drpc.Conn that calls handlers in the same
+// process without a transport.
+//
+// A Conn dispatches every Invoke and NewStream directly to a drpc.Handler,
+// usually a *drpcmux.Mux, so that services registered for a server can be
+// called from inside the process with generated clients:
+//
+//	mux := drpcmux.New()
+//	err := pb.DRPCRegisterCookieMonster(mux, &CookieMonsterServer{})
+//	// ...
+//	client := pb.NewDRPCCookieMonsterClient(drpcinproc.New(mux))
+//
+// The handlers see the same things as when they are called through a
+// drpcserver: a context that is not derived from the caller's but carries its
+// metadata and is canceled with it, and a per-connection drpccache. Errors
+// returned by handlers reach the caller with only their message and code, as
+// they would over the network.
+//
+// By default, messages are still marshaled and unmarshaled with the encoding
+// of the rpc, so that neither side can observe the other's messages. With the
+// Clone option, they are deep copied instead, which is usually cheaper.
+package drpcinproc
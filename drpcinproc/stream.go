@@ -0,0 +1,298 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcinproc
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcenc"
+	"storj.io/drpc/drpcwire"
+)
+
+// these match the errors returned by a drpcstream.Stream in the same states.
+var (
+	sendClosed   = drpc.Error.New("send closed")
+	termError    = drpc.Error.New("stream terminated by sending error")
+	termClosed   = drpc.Error.New("stream terminated by sending close")
+	remoteClosed = drpc.ClosedError.New("remote closed the stream")
+)
+
+const (
+	clientSide = 0
+	serverSide = 1
+)
+
+// item is a message in flight: either its encoded bytes or a clone of it.
+type item struct {
+	data []byte
+	msg  drpc.Message
+}
+
+// end is the state of one side of a stream.
+type end struct {
+	items   []item // sent to this side and not yet received
+	recvErr error  // returned by receives once items is empty
+	sendErr error  // returned by sends
+}
+
+// stream connects a client side and a server side in memory.
+type stream struct {
+	conn *Conn
+	rpc  string
+
+	cctx    context.Context
+	ccancel context.CancelFunc
+	sctx    context.Context
+	scancel context.CancelFunc
+	stop    func() bool
+
+	mu       sync.Mutex
+	ends     [2]end
+	changed  chan struct{}
+	finished bool
+}
+
+// newStream returns a stream for the rpc. The client side context is derived
+// from ctx, and the server side context from sctx. Both are canceled when the
+// stream finishes.
+func newStream(conn *Conn, ctx, sctx context.Context, rpc string) *stream {
+	st := &stream{
+		conn:    conn,
+		rpc:     rpc,
+		changed: make(chan struct{}),
+	}
+	st.cctx, st.ccancel = context.WithCancel(ctx)
+	st.sctx, st.scancel = context.WithCancel(sctx)
+	st.stop = context.AfterFunc(ctx, func() { st.terminate(ctx.Err(), context.Canceled) })
+	return st
+}
+
+// client returns the client side of the stream.
+func (st *stream) client() *side { return &side{st: st, idx: clientSide} }
+
+// server returns the server side of the stream.
+func (st *stream) server() *side { return &side{st: st, idx: serverSide} }
+
+// serve runs the handler for the rpc with the server side of the stream and
+// then sends its error or closes the server side.
+func (st *stream) serve() {
+	server := st.server()
+	if err := st.conn.handler.HandleRPC(server, st.rpc); err != nil {
+		server.sendError(err)
+	} else {
+		_ = server.CloseSend()
+	}
+}
+
+// terminate abruptly ends both sides of the stream with the errors, dropping
+// any messages in flight.
+func (st *stream) terminate(clientErr, serverErr error) {
+	st.mu.Lock()
+	defer st.finish()
+	defer st.mu.Unlock()
+
+	for i, err := range [2]error{clientErr, serverErr} {
+		e := &st.ends[i]
+		e.items = nil
+		setErr(&e.sendErr, err)
+		setErr(&e.recvErr, err)
+	}
+	st.notifyLocked()
+}
+
+// notifyLocked wakes up any blocked receives. It must be called with the mutex
+// held.
+func (st *stream) notifyLocked() {
+	close(st.changed)
+	st.changed = make(chan struct{})
+}
+
+// finish releases the resources of the stream if both sides are done. It must
+// not be called with the mutex held.
+func (st *stream) finish() {
+	st.mu.Lock()
+	done := !st.finished
+	for _, e := range st.ends {
+		done = done && e.sendErr != nil && e.recvErr != nil
+	}
+	if done {
+		st.finished = true
+	}
+	st.mu.Unlock()
+
+	if done {
+		st.stop()
+		st.ccancel()
+		st.scancel()
+		st.conn.remove(st)
+	}
+}
+
+// setErr sets *dst to err if it is not already set.
+func setErr(dst *error, err error) {
+	if *dst == nil {
+		*dst = err
+	}
+}
+
+//
+// side
+//
+
+// side is one side of a stream. It implements drpc.Stream.
+type side struct {
+	st  *stream
+	idx int
+}
+
+var _ drpc.Stream = (*side)(nil)
+
+// local returns the state of this side. It must be called with the mutex held.
+func (s *side) local() *end { return &s.st.ends[s.idx] }
+
+// remote returns the state of the other side. It must be called with the
+// mutex held.
+func (s *side) remote() *end { return &s.st.ends[1-s.idx] }
+
+// Context returns the context of this side of the stream.
+func (s *side) Context() context.Context {
+	if s.idx == clientSide {
+		return s.st.cctx
+	}
+	return s.st.sctx
+}
+
+// MsgSend encodes or clones the message and sends it to the other side.
+func (s *side) MsgSend(msg drpc.Message, enc drpc.Encoding) (err error) {
+	it, err := s.st.conn.encode(msg, enc)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	s.st.mu.Lock()
+	defer s.st.mu.Unlock()
+
+	if err := s.local().sendErr; err != nil {
+		return err
+	}
+	if r := s.remote(); r.recvErr == nil {
+		r.items = append(r.items, it)
+		s.st.notifyLocked()
+	}
+	return nil
+}
+
+// MsgRecv waits for a message from the other side and decodes or copies it
+// into msg.
+func (s *side) MsgRecv(msg drpc.Message, enc drpc.Encoding) (err error) {
+	for {
+		s.st.mu.Lock()
+		l := s.local()
+		if len(l.items) > 0 {
+			it := l.items[0]
+			l.items[0] = item{}
+			l.items = l.items[1:]
+			s.st.mu.Unlock()
+			return s.st.conn.decode(it, msg, enc)
+		} else if err := l.recvErr; err != nil {
+			s.st.mu.Unlock()
+			return err
+		}
+		changed := s.st.changed
+		s.st.mu.Unlock()
+
+		<-changed
+	}
+}
+
+// CloseSend informs the other side that no more messages will be sent.
+func (s *side) CloseSend() (err error) {
+	s.st.mu.Lock()
+	defer s.st.finish()
+	defer s.st.mu.Unlock()
+
+	if s.local().sendErr != nil {
+		return nil
+	}
+	s.local().sendErr = sendClosed
+	setErr(&s.remote().recvErr, io.EOF)
+	s.st.notifyLocked()
+	return nil
+}
+
+// Close ends this side of the stream. The other side receives any messages
+// already sent before io.EOF.
+func (s *side) Close() (err error) {
+	s.st.mu.Lock()
+	defer s.st.finish()
+	defer s.st.mu.Unlock()
+
+	if s.st.finished {
+		return nil
+	}
+
+	l, r := s.local(), s.remote()
+	l.items = nil
+	setErr(&l.sendErr, termClosed)
+	setErr(&l.recvErr, termClosed)
+	setErr(&r.recvErr, io.EOF)
+	setErr(&r.sendErr, remoteClosed)
+	s.st.notifyLocked()
+	return nil
+}
+
+// sendError ends this side of the stream and sends the error to the other
+// side with only its message and code, as a transport would.
+func (s *side) sendError(serr error) {
+	serr = drpcwire.UnmarshalError(drpcwire.MarshalError(serr))
+
+	s.st.mu.Lock()
+	defer s.st.finish()
+	defer s.st.mu.Unlock()
+
+	if s.st.finished {
+		return
+	}
+
+	l, r := s.local(), s.remote()
+	l.items = nil
+	setErr(&l.sendErr, io.EOF) // in this state, gRPC returns io.EOF on send.
+	setErr(&l.recvErr, termError)
+	setErr(&r.recvErr, serr)
+	setErr(&r.sendErr, io.EOF)
+	s.st.notifyLocked()
+}
+
+//
+// message copying
+//
+
+// encode returns the item to send for the message.
+func (c *Conn) encode(msg drpc.Message, enc drpc.Encoding) (item, error) {
+	if !c.opts.Clone {
+		data, err := drpcenc.MarshalAppend(msg, enc, nil)
+		return item{data: data}, err
+	}
+
+	rv := reflect.ValueOf(msg)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return item{}, errs.New("cannot clone non-pointer message %T", msg)
+	}
+	clone := reflect.New(rv.Type().Elem()).Interface()
+	return item{msg: clone}, c.opts.Copy(clone, msg)
+}
+
+// decode fills in msg from the received item.
+func (c *Conn) decode(it item, msg drpc.Message, enc drpc.Encoding) error {
+	if it.msg != nil {
+		return c.opts.Copy(msg, it.msg)
+	}
+	return enc.Unmarshal(it.data, msg)
+}
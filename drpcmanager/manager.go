@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"strings"
 	"syscall"
@@ -52,6 +53,25 @@ type Options struct {
 	// no timeout is used.
 	InactivityTimeout time.Duration
 
+	// MaximumPacketRate is the maximum number of packets per second that the
+	// remote may send. If it is exceeded, the transport is closed. If zero or
+	// negative, the rate is unlimited.
+	MaximumPacketRate int
+
+	// MaximumInvokeMetadata is the maximum number of metadata packets that
+	// the remote may send before invoking an rpc. If it is exceeded, creating
+	// the server stream fails and the transport should be closed. If zero or
+	// negative, the number is unlimited.
+	MaximumInvokeMetadata int
+
+	// Logger, if set, is used to log structured events about the transport
+	// and its streams, like streams being canceled.
+	Logger *slog.Logger
+
+	// Tracer receives debug events about the transport and its streams. If
+	// nil, drpcdebug.Global is used.
+	Tracer *drpcdebug.Tracer
+
 	// Internal contains options that are for internal use only.
 	Internal drpcopts.Manager
 }
@@ -84,6 +104,7 @@ type Manager struct {
 type streamInfo struct {
 	ctx    context.Context
 	stream *drpcstream.Stream
+	rpc    string
 }
 
 // New returns a new Manager for the transport.
@@ -94,9 +115,12 @@ func New(tr drpc.Transport) *Manager {
 // NewWithOptions returns a new manager for the transport. It uses the provided
 // options to manage details of how it uses it.
 func NewWithOptions(tr drpc.Transport, opts Options) *Manager {
+	if opts.Tracer == nil {
+		opts.Tracer = drpcdebug.Global
+	}
+
 	m := &Manager{
 		tr:   tr,
-		wr:   drpcwire.NewWriter(tr, opts.WriterBufferSize),
 		rd:   drpcwire.NewReaderWithOptions(tr, opts.Reader),
 		opts: opts,
 
@@ -108,6 +132,15 @@ func NewWithOptions(tr drpc.Transport, opts Options) *Manager {
 	// initialize the stream buffer
 	m.sbuf.init()
 
+	// the writer traces its flushes for the rpc of the current stream.
+	m.wr = drpcwire.NewWriterWithOptions(tr, opts.WriterBufferSize, drpcwire.WriterOptions{
+		Tracer: opts.Tracer,
+		RPC: func() string {
+			_, rpc := m.sbuf.Current()
+			return rpc
+		},
+	})
+
 	// this semaphore controls the number of concurrent streams. it MUST be 1.
 	m.sem.Make(1)
 
@@ -118,6 +151,7 @@ func NewWithOptions(tr drpc.Transport, opts Options) *Manager {
 	// set the internal stream options
 	drpcopts.SetStreamTransport(&m.opts.Stream.Internal, m.tr)
 	drpcopts.SetStreamFin(&m.opts.Stream.Internal, m.sfin)
+	drpcopts.SetStreamTracer(&m.opts.Stream.Internal, m.opts.Tracer)
 
 	go m.manageReader()
 	go m.manageStreams()
@@ -128,12 +162,47 @@ func NewWithOptions(tr drpc.Transport, opts Options) *Manager {
 // String returns a string representation of the manager.
 func (m *Manager) String() string { return fmt.Sprintf("<man %p>", m) }
 
-func (m *Manager) log(what string, cb func() string) {
-	if drpcdebug.Enabled {
-		drpcdebug.Log(func() (_, _, _ string) { return m.String(), what, cb() })
+// log writes the debug event to the Tracer. The rpc is empty for events that
+// are not about a specific rpc.
+func (m *Manager) log(rpc, what string, cb func() string) {
+	if m.opts.Tracer.Enabled(rpc) {
+		m.opts.Tracer.Log(rpc, func() (_, _, _ string) { return m.String(), what, cb() })
 	}
 }
 
+// packetRPC returns the rpc of the stream that the packet read is for, or the
+// empty string if it is not known, like for the invoke metadata packets that
+// are read before the invoke packet naming the rpc.
+func (m *Manager) packetRPC(pkt drpcwire.Packet) string {
+	if pkt.Kind == drpcwire.KindInvoke {
+		return string(pkt.Data)
+	}
+	if curr, rpc := m.sbuf.Current(); curr != nil && pkt.ID.Stream == curr.ID() {
+		return rpc
+	}
+	return ""
+}
+
+// logStream logs the event about the stream to the Logger if it is set and
+// enabled for the level.
+func (m *Manager) logStream(level slog.Level, msg string, stream *drpcstream.Stream, rpc string, err error) {
+	if m.opts.Logger == nil || !m.opts.Logger.Enabled(context.Background(), level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.Uint64("stream_id", stream.ID()),
+		slog.String("rpc", rpc),
+	}
+	if ra, ok := m.tr.(interface{ RemoteAddr() net.Addr }); ok && ra.RemoteAddr() != nil {
+		attrs = append(attrs, slog.String("remote_addr", ra.RemoteAddr().String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	m.opts.Logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
 //
 // helpers
 //
@@ -179,7 +248,7 @@ func (m *Manager) waitForPreviousStream(ctx context.Context) (err error) {
 		return nil
 	}
 
-	m.log("WAIT", prev.String)
+	m.log("", "WAIT", prev.String)
 
 	select {
 	case <-ctx.Done():
@@ -197,7 +266,7 @@ func (m *Manager) waitForPreviousStream(ctx context.Context) (err error) {
 // that need to be closed to signal the state change.
 func (m *Manager) terminate(err error) {
 	if m.sigs.term.Set(err) {
-		m.log("TERM", func() string { return fmt.Sprint(err) })
+		m.log("", "TERM", func() string { return fmt.Sprint(err) })
 		m.sigs.tport.Set(m.tr.Close())
 		m.sbuf.Close()
 	}
@@ -217,6 +286,7 @@ func (m *Manager) manageReader() {
 	var pkt drpcwire.Packet
 	var err error
 	var run int
+	var rate packetRate
 
 	for !m.sigs.term.IsSet() {
 		// if we have a run of "small" packets, drop the buffer to release
@@ -242,7 +312,14 @@ func (m *Manager) manageReader() {
 			run = 0
 		}
 
-		m.log("READ", pkt.String)
+		if limit := m.opts.MaximumPacketRate; limit > 0 && !rate.allow(limit, time.Now()) {
+			m.terminate(managerClosed.Wrap(drpc.ProtocolError.New("packet rate exceeded (limit:%d/s)", limit)))
+			return
+		}
+
+		if m.opts.Tracer.Active() {
+			m.log(m.packetRPC(pkt), "READ", pkt.String)
+		}
 
 	again:
 		switch curr := m.sbuf.Get(); {
@@ -302,9 +379,9 @@ func (m *Manager) newStream(ctx context.Context, sid uint64, kind, rpc string) (
 
 	stream := drpcstream.NewWithOptions(ctx, sid, m.wr, opts)
 	select {
-	case m.streams <- streamInfo{ctx: ctx, stream: stream}:
-		m.sbuf.Set(stream)
-		m.log("STREAM", stream.String)
+	case m.streams <- streamInfo{ctx: ctx, stream: stream, rpc: rpc}:
+		m.sbuf.Set(stream, rpc)
+		m.log(rpc, "STREAM", stream.String)
 		return stream, nil
 
 	case <-m.sigs.term.Signal():
@@ -320,7 +397,7 @@ func (m *Manager) manageStreams() {
 	for {
 		select {
 		case si := <-m.streams:
-			m.manageStream(si.ctx, si.stream)
+			m.manageStream(si.ctx, si.stream, si.rpc)
 
 		case <-m.sigs.term.Signal():
 			return
@@ -330,7 +407,7 @@ func (m *Manager) manageStreams() {
 
 // manageStream watches the context and the stream and returns when the stream
 // is finished, canceling the stream if the context is canceled.
-func (m *Manager) manageStream(ctx context.Context, stream *drpcstream.Stream) {
+func (m *Manager) manageStream(ctx context.Context, stream *drpcstream.Stream, rpc string) {
 	select {
 	case <-m.sigs.term.Signal():
 		err := m.sigs.term.Err()
@@ -345,7 +422,8 @@ func (m *Manager) manageStream(ctx context.Context, stream *drpcstream.Stream) {
 		m.sem.Recv()
 
 	case <-ctx.Done():
-		m.log("CANCEL", stream.String)
+		m.log(rpc, "CANCEL", stream.String)
+		m.logStream(slog.LevelDebug, "stream canceled", stream, rpc, ctx.Err())
 
 		if m.opts.SoftCancel {
 			// allow a new stream to begin.
@@ -356,7 +434,8 @@ func (m *Manager) manageStream(ctx context.Context, stream *drpcstream.Stream) {
 			if busy, err := stream.SendCancel(ctx.Err()); err != nil {
 				m.terminate(err)
 			} else if busy {
-				m.log("BUSY", stream.String)
+				m.log(rpc, "BUSY", stream.String)
+				m.logStream(slog.LevelDebug, "soft cancel busy", stream, rpc, nil)
 				m.terminate(ctx.Err())
 			}
 			stream.Cancel(ctx.Err())
@@ -368,10 +447,10 @@ func (m *Manager) manageStream(ctx context.Context, stream *drpcstream.Stream) {
 			// transport to do an active cancel. If it is already finished,
 			// there is no need.
 			if !stream.Cancel(ctx.Err()) {
-				m.log("UNFIN", stream.String)
+				m.log(rpc, "UNFIN", stream.String)
 				m.terminate(ctx.Err())
 			} else {
-				m.log("CLEAN", stream.String)
+				m.log(rpc, "CLEAN", stream.String)
 			}
 
 			// wait for the stream to signal that it is finished.
@@ -439,6 +518,7 @@ func (m *Manager) NewServerStream(ctx context.Context) (stream *drpcstream.Strea
 
 	var meta map[string]string
 	var metaID uint64
+	var metaCount int
 	var timeoutCh <-chan time.Time
 
 	// set up the timeout on the context if necessary.
@@ -472,6 +552,11 @@ func (m *Manager) NewServerStream(ctx context.Context) (stream *drpcstream.Strea
 				}
 				metaID = pkt.ID.Stream
 
+				metaCount++
+				if limit := m.opts.MaximumInvokeMetadata; limit > 0 && metaCount > limit {
+					return nil, "", drpc.ProtocolError.New("too many metadata packets before invoke (limit:%d)", limit)
+				}
+
 			case drpcwire.KindInvoke:
 				rpc = string(pkt.Data)
 				m.pdone.Send()
@@ -491,6 +576,22 @@ func (m *Manager) NewServerStream(ctx context.Context) (stream *drpcstream.Strea
 	}
 }
 
+// packetRate counts packets in one second windows.
+type packetRate struct {
+	start time.Time
+	count int
+}
+
+// allow records a packet at the given time and returns false if more than
+// limit packets have been recorded in the current window.
+func (r *packetRate) allow(limit int, now time.Time) bool {
+	if now.Sub(r.start) >= time.Second {
+		r.start, r.count = now, 0
+	}
+	r.count++
+	return r.count <= limit
+}
+
 func isConnectionReset(err error) bool {
 	var operr *net.OpError
 	if !errors.As(err, &operr) {
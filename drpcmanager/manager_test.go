@@ -4,10 +4,12 @@
 package drpcmanager
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"net"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -38,6 +40,70 @@ func TestTimeout(t *testing.T) {
 	assert.That(t, errors.Is(err, context.DeadlineExceeded))
 }
 
+func TestMaximumInvokeMetadata(t *testing.T) {
+	tr := newPacketTransport(metadataPackets(3)...)
+	man := NewWithOptions(tr, Options{MaximumInvokeMetadata: 2})
+	defer func() { _ = man.Close() }()
+
+	_, _, err := man.NewServerStream(context.Background())
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "too many metadata packets"))
+}
+
+func TestMaximumPacketRate(t *testing.T) {
+	tr := newPacketTransport(metadataPackets(5)...)
+	man := NewWithOptions(tr, Options{MaximumPacketRate: 3})
+	defer func() { _ = man.Close() }()
+
+	_, _, err := man.NewServerStream(context.Background())
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "packet rate exceeded"))
+}
+
+func metadataPackets(n int) (pkts []drpcwire.Packet) {
+	for i := 1; i <= n; i++ {
+		pkts = append(pkts, drpcwire.Packet{
+			ID:   drpcwire.ID{Stream: uint64(i), Message: 1},
+			Kind: drpcwire.KindInvokeMetadata,
+		})
+	}
+	return pkts
+}
+
+// packetTransport reads the packets it was created with and then blocks
+// until it is closed.
+type packetTransport struct {
+	buf  *bytes.Reader
+	done chan struct{}
+}
+
+func newPacketTransport(pkts ...drpcwire.Packet) *packetTransport {
+	var buf []byte
+	for _, pkt := range pkts {
+		buf = drpcwire.AppendFrame(buf, drpcwire.Frame{
+			Data: pkt.Data,
+			ID:   pkt.ID,
+			Kind: pkt.Kind,
+			Done: true,
+		})
+	}
+	return &packetTransport{
+		buf:  bytes.NewReader(buf),
+		done: make(chan struct{}),
+	}
+}
+
+func (p *packetTransport) Read(b []byte) (n int, err error) {
+	if p.buf.Len() > 0 {
+		return p.buf.Read(b)
+	}
+	<-p.done
+	return 0, io.EOF
+}
+
+func (p *packetTransport) Write(b []byte) (n int, err error) { return len(b), nil }
+func (p *packetTransport) Close() error                      { close(p.done); return nil }
+
 type blockingTransport chan struct{}
 
 func (b blockingTransport) Read(p []byte) (n int, err error)  { <-b; return 0, io.EOF }
@@ -13,10 +13,16 @@ import (
 type streamBuffer struct {
 	mu     sync.Mutex
 	cond   sync.Cond
-	stream atomic.Pointer[drpcstream.Stream]
+	curr   atomic.Pointer[bufferedStream]
 	closed bool
 }
 
+// bufferedStream is the stream in a streamBuffer and the rpc it is for.
+type bufferedStream struct {
+	stream *drpcstream.Stream
+	rpc    string
+}
+
 func (sb *streamBuffer) init() {
 	sb.cond.L = &sb.mu
 }
@@ -30,10 +36,18 @@ func (sb *streamBuffer) Close() {
 }
 
 func (sb *streamBuffer) Get() *drpcstream.Stream {
-	return sb.stream.Load()
+	stream, _ := sb.Current()
+	return stream
+}
+
+func (sb *streamBuffer) Current() (*drpcstream.Stream, string) {
+	if curr := sb.curr.Load(); curr != nil {
+		return curr.stream, curr.rpc
+	}
+	return nil, ""
 }
 
-func (sb *streamBuffer) Set(stream *drpcstream.Stream) {
+func (sb *streamBuffer) Set(stream *drpcstream.Stream, rpc string) {
 	sb.mu.Lock()
 	defer sb.mu.Unlock()
 
@@ -41,7 +55,7 @@ func (sb *streamBuffer) Set(stream *drpcstream.Stream) {
 		return
 	}
 
-	sb.stream.Store(stream)
+	sb.curr.Store(&bufferedStream{stream: stream, rpc: rpc})
 	sb.cond.Broadcast()
 }
 
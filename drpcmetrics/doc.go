@@ -0,0 +1,11 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcmetrics exposes collected drpcstats in the OpenMetrics text
+// format.
+//
+// The Handler serves the stats of any registered sources, like servers and
+// connections created with the CollectStats option, so that they can be
+// scraped by Prometheus or any other OpenMetrics compatible collector. It is
+// written without depending on any client library.
+package drpcmetrics
@@ -0,0 +1,147 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcmetrics
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"storj.io/drpc/drpcstats"
+)
+
+// the ranges of histogram buckets that are exposed. latencies are from about a
+// microsecond to about a minute and sizes are from 16 bytes to 64 megabytes.
+// observations outside of the range are still included in the count and sum.
+const (
+	latencyLo, latencyHi = 10, 36
+	sizeLo, sizeHi       = 4, 26
+)
+
+// exposition builds the OpenMetrics text for a set of series.
+type exposition struct {
+	ns  string
+	buf bytes.Buffer
+}
+
+// write appends every metric family for the series followed by the EOF marker.
+func (e *exposition) write(ser []*series) {
+	e.counter(ser, "calls", "Number of rpcs started.",
+		func(s *drpcstats.Stats) uint64 { return s.Calls })
+	e.gauge(ser, "in_flight", "Number of rpcs that have not finished.",
+		func(s *drpcstats.Stats) int64 { return s.InFlight })
+	e.counter(ser, "timeouts", "Number of rpcs that exceeded their handler timeout.",
+		func(s *drpcstats.Stats) uint64 { return s.Timeouts })
+	e.errors(ser)
+	e.counter(ser, "read_bytes", "Number of bytes read.",
+		func(s *drpcstats.Stats) uint64 { return s.Read })
+	e.counter(ser, "written_bytes", "Number of bytes written.",
+		func(s *drpcstats.Stats) uint64 { return s.Written })
+	e.counter(ser, "messages_read", "Number of messages read.",
+		func(s *drpcstats.Stats) uint64 { return s.MessagesRead })
+	e.counter(ser, "messages_written", "Number of messages written.",
+		func(s *drpcstats.Stats) uint64 { return s.MessagesWritten })
+	e.histogram(ser, "latency_seconds", "Duration of finished rpcs.", latencyLo, latencyHi, 1e9,
+		func(s *drpcstats.Stats) *drpcstats.Histogram { return &s.Latency })
+	e.histogram(ser, "message_read_size_bytes", "Size of messages read.", sizeLo, sizeHi, 1,
+		func(s *drpcstats.Stats) *drpcstats.Histogram { return &s.ReadSizes })
+	e.histogram(ser, "message_written_size_bytes", "Size of messages written.", sizeLo, sizeHi, 1,
+		func(s *drpcstats.Stats) *drpcstats.Histogram { return &s.WrittenSizes })
+
+	e.buf.WriteString("# EOF\n")
+}
+
+// family writes the metadata lines for a metric family and returns its name.
+func (e *exposition) family(name, typ, help string) string {
+	name = e.ns + "_" + name
+	e.buf.WriteString("# TYPE " + name + " " + typ + "\n")
+	e.buf.WriteString("# HELP " + name + " " + help + "\n")
+	return name
+}
+
+// counter writes a counter family with a sample for every series.
+func (e *exposition) counter(ser []*series, name, help string, val func(*drpcstats.Stats) uint64) {
+	name = e.family(name, "counter", help)
+	for _, s := range ser {
+		e.sample(name+"_total", s, "", "", strconv.FormatUint(val(&s.stats), 10))
+	}
+}
+
+// gauge writes a gauge family with a sample for every series.
+func (e *exposition) gauge(ser []*series, name, help string, val func(*drpcstats.Stats) int64) {
+	name = e.family(name, "gauge", help)
+	for _, s := range ser {
+		e.sample(name, s, "", "", strconv.FormatInt(val(&s.stats), 10))
+	}
+}
+
+// errors writes the counter family of errors with a sample for every code of
+// every series.
+func (e *exposition) errors(ser []*series) {
+	name := e.family("errors", "counter", "Number of rpcs that failed by error code.")
+	for _, s := range ser {
+		codes := make([]uint64, 0, len(s.errors))
+		for code := range s.errors {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+		for _, code := range codes {
+			e.sample(name+"_total", s, "code", strconv.FormatUint(code, 10),
+				strconv.FormatUint(s.errors[code], 10))
+		}
+	}
+}
+
+// histogram writes a histogram family with the buckets from lo to hi for
+// every series. The values are divided by scale to convert their units.
+func (e *exposition) histogram(ser []*series, name, help string, lo, hi int, scale float64,
+	hist func(*drpcstats.Stats) *drpcstats.Histogram) {
+
+	name = e.family(name, "histogram", help)
+	for _, s := range ser {
+		h := hist(&s.stats)
+
+		var cum uint64
+		for i := range h.Buckets {
+			cum += h.Buckets[i]
+			if i >= lo && i <= hi {
+				le := formatFloat(float64(drpcstats.BucketBound(i)) / scale)
+				e.sample(name+"_bucket", s, "le", le, strconv.FormatUint(cum, 10))
+			}
+		}
+		e.sample(name+"_bucket", s, "le", "+Inf", strconv.FormatUint(cum, 10))
+		e.sample(name+"_count", s, "", "", strconv.FormatUint(cum, 10))
+		e.sample(name+"_sum", s, "", "", formatFloat(float64(h.Sum)/scale))
+	}
+}
+
+// sample writes a single sample line for the series with an optional extra
+// label.
+func (e *exposition) sample(name string, s *series, label, value, val string) {
+	e.buf.WriteString(name)
+	e.buf.WriteString(`{side="`)
+	e.buf.WriteString(escape(s.side))
+	e.buf.WriteString(`",rpc="`)
+	e.buf.WriteString(escape(s.rpc))
+	if label != "" {
+		e.buf.WriteString(`",` + label + `="`)
+		e.buf.WriteString(escape(value))
+	}
+	e.buf.WriteString(`"} `)
+	e.buf.WriteString(val)
+	e.buf.WriteString("\n")
+}
+
+// formatFloat formats the float in the shortest form that round trips.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// labelEscaper escapes the characters that are not allowed in label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escape returns the label value with any special characters escaped.
+func escape(v string) string { return labelEscaper.Replace(v) }
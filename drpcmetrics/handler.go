@@ -0,0 +1,174 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcmetrics
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"storj.io/drpc/drpcstats"
+)
+
+// ContentType is the content type of the responses served by the Handler.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Source is anything that reports stats grouped by rpc, like a
+// *drpcserver.Server or a *drpcconn.Conn. Sources must be comparable, like
+// pointers, so that they can be removed.
+type Source interface {
+	Stats() map[string]drpcstats.Stats
+}
+
+// Options controls configuration settings for a Handler.
+type Options struct {
+	// Namespace is prepended to the name of every metric with an underscore.
+	// If empty, "drpc" is used.
+	Namespace string
+}
+
+// Handler is an http.Handler that serves the stats of its sources in the
+// OpenMetrics text format. Stats from sources of the same side are summed
+// together by rpc.
+type Handler struct {
+	opts Options
+
+	mu      sync.Mutex
+	sources []source
+}
+
+// source is a registered Source and the side label its stats are exposed
+// with.
+type source struct {
+	side string
+	src  Source
+}
+
+// NewHandler constructs a new Handler with no sources.
+func NewHandler(opts Options) *Handler {
+	if opts.Namespace == "" {
+		opts.Namespace = "drpc"
+	}
+	return &Handler{opts: opts}
+}
+
+// AddServer registers the source to be exposed with the "server" side label.
+func (h *Handler) AddServer(src Source) { h.add("server", src) }
+
+// AddClient registers the source to be exposed with the "client" side label.
+func (h *Handler) AddClient(src Source) { h.add("client", src) }
+
+// add registers the source with the side label.
+func (h *Handler) add(side string, src Source) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sources = append(h.sources, source{side: side, src: src})
+}
+
+// Remove unregisters the source so that its stats are no longer exposed. Note
+// that this causes the exposed counters to decrease, which collectors treat
+// as a counter reset.
+func (h *Handler) Remove(src Source) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sources := h.sources[:0]
+	for _, s := range h.sources {
+		if s.src != src {
+			sources = append(sources, s)
+		}
+	}
+	h.sources = sources
+}
+
+// ServeHTTP serves the stats of every source in the OpenMetrics text format.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var buf bytes.Buffer
+	_, _ = h.WriteTo(&buf)
+
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// WriteTo writes the stats of every source in the OpenMetrics text format to
+// w.
+func (h *Handler) WriteTo(w io.Writer) (int64, error) {
+	ew := &exposition{ns: h.opts.Namespace}
+	ew.write(h.collect())
+	return ew.buf.WriteTo(w)
+}
+
+// series is the summed stats of an rpc on one side.
+type series struct {
+	side   string
+	rpc    string
+	stats  drpcstats.Stats
+	errors map[uint64]uint64
+}
+
+// collect sums the stats of the sources by side and rpc and returns them in
+// a stable order.
+func (h *Handler) collect() []*series {
+	h.mu.Lock()
+	sources := append([]source(nil), h.sources...)
+	h.mu.Unlock()
+
+	type key struct{ side, rpc string }
+	summed := make(map[key]*series)
+	var out []*series
+
+	for _, s := range sources {
+		for rpc, stats := range s.src.Stats() {
+			ser := summed[key{s.side, rpc}]
+			if ser == nil {
+				ser = &series{side: s.side, rpc: rpc}
+				summed[key{s.side, rpc}] = ser
+				out = append(out, ser)
+			}
+			merge(ser, stats)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].side != out[j].side {
+			return out[i].side < out[j].side
+		}
+		return out[i].rpc < out[j].rpc
+	})
+	return out
+}
+
+// merge adds the stats in src into the series.
+func merge(ser *series, src drpcstats.Stats) {
+	dst := &ser.stats
+	dst.Read += src.Read
+	dst.Written += src.Written
+	dst.Timeouts += src.Timeouts
+	dst.Calls += src.Calls
+	dst.InFlight += src.InFlight
+	dst.MessagesRead += src.MessagesRead
+	dst.MessagesWritten += src.MessagesWritten
+
+	mergeHistogram(&dst.Latency, &src.Latency)
+	mergeHistogram(&dst.ReadSizes, &src.ReadSizes)
+	mergeHistogram(&dst.WrittenSizes, &src.WrittenSizes)
+
+	for code, n := range src.Errors() {
+		if ser.errors == nil {
+			ser.errors = make(map[uint64]uint64)
+		}
+		ser.errors[code] += n
+	}
+}
+
+// mergeHistogram adds the observations in src into dst.
+func mergeHistogram(dst, src *drpcstats.Histogram) {
+	for i := range src.Buckets {
+		dst.Buckets[i] += src.Buckets[i]
+	}
+	dst.Sum += src.Sum
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcmetrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc/drpcstats"
+)
+
+type staticSource struct{ stats map[string]drpcstats.Stats }
+
+func (s *staticSource) Stats() map[string]drpcstats.Stats { return s.stats }
+
+func stats(calls int, codes ...uint64) drpcstats.Stats {
+	var s drpcstats.Stats
+	for i := 0; i < calls; i++ {
+		s.StartCall()
+		s.AddMessageRead(100)
+		s.FinishCall(time.Millisecond)
+	}
+	for _, code := range codes {
+		s.AddError(code)
+	}
+	return s.AtomicClone()
+}
+
+func TestHandler(t *testing.T) {
+	srv1 := &staticSource{map[string]drpcstats.Stats{"/service.Service/Method": stats(2, 5)}}
+	srv2 := &staticSource{map[string]drpcstats.Stats{"/service.Service/Method": stats(1, 5, 14)}}
+	cli := &staticSource{map[string]drpcstats.Stats{`/odd"rpc`: stats(3)}}
+
+	h := NewHandler(Options{})
+	h.AddServer(srv1)
+	h.AddServer(srv2)
+	h.AddClient(cli)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, rec.Header().Get("Content-Type"), ContentType)
+
+	body, err := io.ReadAll(rec.Body)
+	assert.NoError(t, err)
+	out := string(body)
+
+	for _, line := range []string{
+		`# TYPE drpc_calls counter`,
+		`drpc_calls_total{side="server",rpc="/service.Service/Method"} 3`,
+		`drpc_calls_total{side="client",rpc="/odd\"rpc"} 3`,
+		`drpc_in_flight{side="server",rpc="/service.Service/Method"} 0`,
+		`drpc_errors_total{side="server",rpc="/service.Service/Method",code="5"} 2`,
+		`drpc_errors_total{side="server",rpc="/service.Service/Method",code="14"} 1`,
+		`drpc_messages_read_total{side="server",rpc="/service.Service/Method"} 3`,
+		`drpc_latency_seconds_bucket{side="server",rpc="/service.Service/Method",le="+Inf"} 3`,
+		`drpc_latency_seconds_count{side="server",rpc="/service.Service/Method"} 3`,
+		`drpc_latency_seconds_sum{side="server",rpc="/service.Service/Method"} 0.003`,
+		`drpc_message_read_size_bytes_bucket{side="server",rpc="/service.Service/Method",le="63"} 0`,
+		`drpc_message_read_size_bytes_bucket{side="server",rpc="/service.Service/Method",le="127"} 3`,
+	} {
+		assert.That(t, strings.Contains(out, line+"\n"))
+	}
+	assert.That(t, strings.HasSuffix(out, "# EOF\n"))
+
+	// client series sort before server series
+	assert.That(t, strings.Index(out, `side="client"`) < strings.Index(out, `side="server"`))
+
+	// removed sources are no longer exposed
+	h.Remove(srv2)
+	var buf strings.Builder
+	_, err = h.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.That(t, strings.Contains(buf.String(), `drpc_calls_total{side="server",rpc="/service.Service/Method"} 2`+"\n"))
+}
@@ -19,11 +19,8 @@ func (m *Mux) HandleRPC(stream drpc.Stream, rpc string) (err error) {
 	}
 
 	in := interface{}(stream)
-	if data.in1 != streamType {
-		msg, ok := reflect.New(data.in1.Elem()).Interface().(drpc.Message)
-		if !ok {
-			return drpc.InternalError.New("invalid rpc input type")
-		}
+	if data.newIn != nil {
+		msg := data.newIn()
 		if err := stream.MsgRecv(msg, data.enc); err != nil {
 			return errs.Wrap(err)
 		}
@@ -24,66 +24,149 @@ func New() *Mux {
 	}
 }
 
-var (
-	streamType  = reflect.TypeOf((*drpc.Stream)(nil)).Elem()
-	messageType = reflect.TypeOf((*drpc.Message)(nil)).Elem()
-)
-
 type rpcData struct {
-	srv      interface{}
-	enc      drpc.Encoding
-	receiver drpc.Receiver
-	in1      reflect.Type
-	in2      reflect.Type
-	unitary  bool
+	srv        interface{}
+	enc        drpc.Encoding
+	receiver   drpc.Receiver
+	newIn      func() drpc.Message // nil if the rpc receives a stream
+	kind       drpc.MethodKind
+	in         reflect.Type
+	out        reflect.Type
+	descriptor interface{}
+	options    []byte
 }
 
 // Register associates the RPCs described by the description in the server.
-// It returns an error if there was a problem registering it.
+// It returns an error if there was a problem registering it. If the
+// description implements drpc.DescriptionInfo, the shape of the methods is
+// taken from it instead of inspecting the method expressions, and if it
+// implements drpc.DescriptionOptions, the options of the methods are
+// available from RPC and RPCs.
 func (m *Mux) Register(srv interface{}, desc drpc.Description) error {
+	dinfo, _ := desc.(drpc.DescriptionInfo)
+	dopts, _ := desc.(drpc.DescriptionOptions)
+
 	n := desc.NumMethods()
 	for i := 0; i < n; i++ {
 		rpc, enc, receiver, method, ok := desc.Method(i)
 		if !ok {
 			return errs.New("Description returned invalid method for index %d", i)
 		}
-		if err := m.registerOne(srv, rpc, enc, receiver, method); err != nil {
+		data := rpcData{srv: srv, enc: enc, receiver: receiver}
+		if dopts != nil {
+			data.options = dopts.MethodOptions(i)
+		}
+		var info *drpc.MethodInfo
+		if dinfo != nil {
+			if mi, ok := dinfo.MethodInfo(i); ok {
+				info = &mi
+			}
+		}
+		if err := m.registerOne(rpc, data, method, info); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// registerOne does the work to register a single rpc.
-func (m *Mux) registerOne(srv interface{}, rpc string, enc drpc.Encoding, receiver drpc.Receiver, method interface{}) error {
-	data := rpcData{srv: srv, enc: enc, receiver: receiver}
+// registerOne does the work to register a single rpc. The shape of the rpc is
+// taken from the info if it is not nil, and from the method otherwise.
+func (m *Mux) registerOne(rpc string, data rpcData, method interface{}, info *drpc.MethodInfo) (err error) {
+	if info != nil {
+		err = data.fromInfo(*info)
+	} else {
+		err = data.fromMethod(method)
+	}
+	if err != nil {
+		return err
+	}
+	m.rpcs[rpc] = data
+	return nil
+}
+
+// fromInfo fills in the shape of the rpc from the info.
+func (data *rpcData) fromInfo(info drpc.MethodInfo) error {
+	switch info.Kind {
+	case drpc.MethodUnary, drpc.MethodServerStream:
+		if info.NewIn == nil {
+			return errs.New("no input constructor for method: %s", info.Method)
+		}
+		data.newIn = info.NewIn
+	case drpc.MethodClientStream, drpc.MethodBidiStream:
+	default:
+		return errs.New("unknown method kind for method %s: %d", info.Method, info.Kind)
+	}
 
+	data.kind = info.Kind
+	data.in = messageType(info.NewIn)
+	data.out = messageType(info.NewOut)
+	data.descriptor = info.Descriptor
+	return nil
+}
+
+// fromMethod fills in the shape of the rpc by inspecting the parameters of
+// the method expression.
+func (data *rpcData) fromMethod(method interface{}) error {
 	switch mt := reflect.TypeOf(method); {
 	// unitary input, unitary output
 	case mt.NumOut() == 2:
-		data.unitary = true
-		data.in1 = mt.In(2)
-		if !data.in1.Implements(messageType) {
-			return errs.New("input argument not a drpc message: %v", data.in1)
-		}
+		data.kind, data.in, data.out = drpc.MethodUnary, mt.In(2), mt.Out(0)
+		data.newIn = messageConstructor(data.in)
 
 	// unitary input, stream output
 	case mt.NumIn() == 3:
-		data.in1 = mt.In(1)
-		if !data.in1.Implements(messageType) {
-			return errs.New("input argument not a drpc message: %v", data.in1)
-		}
-		data.in2 = streamType
+		data.kind, data.in = drpc.MethodServerStream, mt.In(1)
+		data.out = streamMessage(mt.In(2), "Send", true)
+		data.newIn = messageConstructor(data.in)
 
 	// stream input
 	case mt.NumIn() == 2:
-		data.in1 = streamType
+		data.in = streamMessage(mt.In(1), "Recv", false)
+		if out := streamMessage(mt.In(1), "SendAndClose", true); out != nil {
+			data.kind, data.out = drpc.MethodClientStream, out
+		} else {
+			data.kind, data.out = drpc.MethodBidiStream, streamMessage(mt.In(1), "Send", true)
+		}
 
-	// code gen bug?
+	// the method expression does not have the signature of any kind of
+	// rpc, so the description was not generated by protoc-gen-go-drpc or was
+	// written by hand incorrectly.
 	default:
 		return errs.New("unknown method type: %v", mt)
 	}
-
-	m.rpcs[rpc] = data
 	return nil
 }
+
+// messageType returns the type of the messages returned by fn, or nil if fn
+// is nil.
+func messageType(fn func() drpc.Message) reflect.Type {
+	if fn == nil {
+		return nil
+	}
+	return reflect.TypeOf(fn())
+}
+
+// messageConstructor returns a function that allocates new messages of the
+// pointer type typ.
+func messageConstructor(typ reflect.Type) func() drpc.Message {
+	return func() drpc.Message { return reflect.New(typ.Elem()).Interface() }
+}
+
+// streamMessage returns the message type that the named method of the stream
+// type sends, if send is true, or receives. It returns nil if the stream type
+// has no such method.
+func streamMessage(st reflect.Type, name string, send bool) reflect.Type {
+	m, ok := st.MethodByName(name)
+	switch {
+	case !ok:
+		return nil
+	case send && st.Kind() != reflect.Interface && m.Type.NumIn() == 2:
+		return m.Type.In(1)
+	case send && st.Kind() == reflect.Interface && m.Type.NumIn() == 1:
+		return m.Type.In(0)
+	case !send && m.Type.NumOut() == 2:
+		return m.Type.Out(0)
+	default:
+		return nil
+	}
+}
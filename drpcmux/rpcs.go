@@ -0,0 +1,71 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcmux
+
+import (
+	"reflect"
+	"sort"
+
+	"storj.io/drpc"
+)
+
+// RPC describes an rpc registered with a Mux.
+type RPC struct {
+	// Name is the name that the rpc is invoked with.
+	Name string
+
+	// Kind is the streaming kind of the rpc.
+	Kind drpc.MethodKind
+
+	// In and Out are the types of the messages the rpc receives and sends.
+	// They are nil if they could not be determined from the registered
+	// method.
+	In  reflect.Type
+	Out reflect.Type
+
+	// Descriptor is the descriptor of the rpc's method from the
+	// drpc.MethodInfo of the Description, or nil if it did not provide one.
+	Descriptor interface{}
+
+	// Options are the options declared on the rpc's method in the wire format
+	// of a google.protobuf.MethodOptions message, or nil if the Description
+	// did not provide any. Custom options can be read by unmarshaling them
+	// into a descriptorpb.MethodOptions and calling proto.GetExtension. They
+	// must not be modified.
+	Options []byte
+}
+
+// RPC returns a description of the rpc registered with the Mux with the name.
+// Handlers that wrap the Mux can use it to act on the options of the rpcs
+// they are passed.
+func (m *Mux) RPC(name string) (RPC, bool) {
+	data, ok := m.rpcs[name]
+	if !ok {
+		return RPC{}, false
+	}
+	return data.describe(name), true
+}
+
+// RPCs returns a description of every rpc registered with the Mux sorted by
+// name.
+func (m *Mux) RPCs() []RPC {
+	rpcs := make([]RPC, 0, len(m.rpcs))
+	for name, data := range m.rpcs {
+		rpcs = append(rpcs, data.describe(name))
+	}
+	sort.Slice(rpcs, func(i, j int) bool { return rpcs[i].Name < rpcs[j].Name })
+	return rpcs
+}
+
+// describe returns the description of the rpc with the name.
+func (data rpcData) describe(name string) RPC {
+	return RPC{
+		Name:       name,
+		Kind:       data.kind,
+		In:         data.in,
+		Out:        data.out,
+		Descriptor: data.descriptor,
+		Options:    data.options,
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// Conn wraps a drpc.Conn to instrument the rpcs issued on it.
+type Conn struct {
+	drpc.Conn
+	in *instruments
+}
+
+// NewConn returns a Conn that instruments the rpcs issued on conn.
+func NewConn(conn drpc.Conn, opts Options) *Conn {
+	return &Conn{
+		Conn: conn,
+		in:   newInstruments(opts, trace.SpanKindClient),
+	}
+}
+
+// Invoke issues the rpc on the wrapped connection inside of a client span.
+func (c *Conn) Invoke(ctx context.Context, rpc string, enc drpc.Encoding, in, out drpc.Message) (err error) {
+	ctx, call := c.in.start(ctx, rpc)
+	defer func() { call.end(err) }()
+
+	if err := c.Conn.Invoke(c.inject(ctx), rpc, enc, in, out); err != nil {
+		return err
+	}
+	call.message(true)
+	call.message(false)
+	return nil
+}
+
+// NewStream starts a stream on the wrapped connection inside of a client span
+// that ends when the stream is finished.
+func (c *Conn) NewStream(ctx context.Context, rpc string, enc drpc.Encoding) (_ drpc.Stream, err error) {
+	ctx, call := c.in.start(ctx, rpc)
+
+	st, err := c.Conn.NewStream(c.inject(ctx), rpc, enc)
+	if err != nil {
+		call.end(err)
+		return nil, err
+	}
+
+	wrapped := &stream{Stream: st, ctx: ctx, call: call}
+	go func() {
+		<-st.Context().Done()
+		call.end(wrapped.error())
+	}()
+
+	return wrapped, nil
+}
+
+// inject adds the trace context of ctx to the metadata sent with the rpc.
+func (c *Conn) inject(ctx context.Context) context.Context {
+	carrier := make(propagation.MapCarrier)
+	c.in.prop.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return drpcmetadata.AddPairs(ctx, carrier)
+}
@@ -0,0 +1,15 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcotel provides OpenTelemetry instrumentation for drpc clients and
+// servers.
+//
+// NewConn wraps a drpc.Conn and NewHandler wraps a drpc.Handler so that every
+// rpc gets a span with the standard rpc.* attributes, events for every
+// message sent and received on its streams, and a duration measurement. The
+// trace context is sent from clients to servers in the drpc metadata using
+// the W3C trace context format by default.
+//
+// The package is its own module so that the core drpc module does not depend
+// on OpenTelemetry.
+package drpcotel
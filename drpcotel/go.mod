@@ -0,0 +1,23 @@
+module storj.io/drpc/drpcotel
+
+go 1.21
+
+require (
+	github.com/zeebo/assert v1.3.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+	storj.io/drpc v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/zeebo/errs v1.2.2 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
+
+replace storj.io/drpc => ../
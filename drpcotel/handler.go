@@ -0,0 +1,46 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcotel
+
+import (
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// Handler wraps a drpc.Handler to instrument the rpcs it handles.
+type Handler struct {
+	handler drpc.Handler
+	in      *instruments
+}
+
+// NewHandler returns a Handler that instruments the rpcs handled by handler.
+func NewHandler(handler drpc.Handler, opts Options) *Handler {
+	return &Handler{
+		handler: handler,
+		in:      newInstruments(opts, trace.SpanKindServer),
+	}
+}
+
+// HandleRPC handles the rpc with the wrapped handler inside of a server span
+// that is a child of any trace context sent by the client.
+func (h *Handler) HandleRPC(st drpc.Stream, rpc string) (err error) {
+	ctx := st.Context()
+	if md, ok := drpcmetadata.Get(ctx); ok {
+		ctx = h.in.prop.Extract(ctx, propagation.MapCarrier(md))
+	}
+
+	ctx, call := h.in.start(ctx, rpc)
+	wrapped := &stream{Stream: st, ctx: ctx, call: call}
+
+	err = h.handler.HandleRPC(wrapped, rpc)
+	if err != nil {
+		call.end(err)
+	} else {
+		call.end(wrapped.error())
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcotel
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"storj.io/drpc/drpcerr"
+)
+
+// instrumentationName is the name of the tracer and meter.
+const instrumentationName = "storj.io/drpc/drpcotel"
+
+// the attribute keys used for spans, events and metrics.
+const (
+	rpcSystemKey    = attribute.Key("rpc.system")
+	rpcServiceKey   = attribute.Key("rpc.service")
+	rpcMethodKey    = attribute.Key("rpc.method")
+	rpcErrorCodeKey = attribute.Key("rpc.drpc.error_code")
+	messageTypeKey  = attribute.Key("message.type")
+	messageIDKey    = attribute.Key("message.id")
+)
+
+// Options controls configuration settings for the instrumentation.
+type Options struct {
+	// TracerProvider is used to create spans. If nil, the global provider
+	// is used.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider is used to record rpc durations. If nil, the global
+	// provider is used.
+	MeterProvider metric.MeterProvider
+
+	// Propagator injects and extracts the trace context to and from the drpc
+	// metadata. If nil, the W3C trace context format is used.
+	Propagator propagation.TextMapPropagator
+}
+
+// instruments holds the tracer and metric instruments for one side of rpcs.
+type instruments struct {
+	tracer   trace.Tracer
+	prop     propagation.TextMapPropagator
+	kind     trace.SpanKind
+	duration metric.Float64Histogram
+}
+
+// newInstruments constructs the instruments for the side of rpcs described by
+// the span kind using the options.
+func newInstruments(opts Options, kind trace.SpanKind) *instruments {
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
+	if opts.MeterProvider == nil {
+		opts.MeterProvider = otel.GetMeterProvider()
+	}
+	if opts.Propagator == nil {
+		opts.Propagator = propagation.TraceContext{}
+	}
+
+	name := "rpc.client.duration"
+	if kind == trace.SpanKindServer {
+		name = "rpc.server.duration"
+	}
+
+	// the histogram is a no-op if it could not be created.
+	duration, err := opts.MeterProvider.Meter(instrumentationName).Float64Histogram(name,
+		metric.WithDescription("Measures the duration of rpcs."),
+		metric.WithUnit("ms"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &instruments{
+		tracer:   opts.TracerProvider.Tracer(instrumentationName),
+		prop:     opts.Propagator,
+		kind:     kind,
+		duration: duration,
+	}
+}
+
+// start starts a span for the rpc.
+func (in *instruments) start(ctx context.Context, rpc string) (context.Context, *call) {
+	attrs := rpcAttributes(rpc)
+	ctx, span := in.tracer.Start(ctx, strings.TrimPrefix(rpc, "/"),
+		trace.WithSpanKind(in.kind),
+		trace.WithAttributes(attrs...))
+
+	return ctx, &call{
+		ctx:   ctx,
+		in:    in,
+		span:  span,
+		attrs: attrs,
+		start: time.Now(),
+	}
+}
+
+// rpcAttributes returns the attributes describing an rpc named like
+// "/package.Service/Method".
+func rpcAttributes(rpc string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{rpcSystemKey.String("drpc")}
+	service, method, ok := strings.Cut(strings.TrimPrefix(rpc, "/"), "/")
+	if !ok {
+		return append(attrs, rpcMethodKey.String(rpc))
+	}
+	return append(attrs, rpcServiceKey.String(service), rpcMethodKey.String(method))
+}
+
+// call is the instrumentation state of a single rpc.
+type call struct {
+	ctx   context.Context
+	in    *instruments
+	span  trace.Span
+	attrs []attribute.KeyValue
+	start time.Time
+	sent  int64
+	recv  int64
+}
+
+// message records an event for a message sent or received on the rpc. It is
+// safe to call concurrently for sent and received messages.
+func (c *call) message(sent bool) {
+	typ, id := "RECEIVED", &c.recv
+	if sent {
+		typ, id = "SENT", &c.sent
+	}
+	c.span.AddEvent("message", trace.WithAttributes(
+		messageTypeKey.String(typ),
+		messageIDKey.Int64(atomic.AddInt64(id, 1))))
+}
+
+// end finishes the span and records the duration of the rpc with the error
+// that it failed with, if any.
+func (c *call) end(err error) {
+	attrs := c.attrs
+	if err != nil {
+		code := drpcerr.Code(err)
+		attrs = append(attrs[:len(attrs):len(attrs)], rpcErrorCodeKey.Int64(int64(code)))
+		c.span.SetAttributes(rpcErrorCodeKey.Int64(int64(code)))
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+	}
+	c.span.End()
+
+	if c.in.duration != nil {
+		c.in.duration.Record(c.ctx,
+			float64(time.Since(c.start))/float64(time.Millisecond),
+			metric.WithAttributes(attrs...))
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+)
+
+type stringEncoding struct{}
+
+func (stringEncoding) Marshal(msg drpc.Message) ([]byte, error) {
+	return []byte(*msg.(*string)), nil
+}
+
+func (stringEncoding) Unmarshal(buf []byte, msg drpc.Message) error {
+	*msg.(*string) = string(buf)
+	return nil
+}
+
+type handlerFunc func(stream drpc.Stream, rpc string) error
+
+func (fn handlerFunc) HandleRPC(stream drpc.Stream, rpc string) error { return fn(stream, rpc) }
+
+func TestInstrumentation(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	opts := Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+
+	serverTraces := make(chan trace.TraceID, 3)
+	handler := NewHandler(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		serverTraces <- trace.SpanContextFromContext(stream.Context()).TraceID()
+
+		var in string
+		if err := stream.MsgRecv(&in, stringEncoding{}); err != nil {
+			return err
+		}
+		switch rpc {
+		case "/service.Service/Fail":
+			return drpcerr.WithCode(errors.New("failed"), 5)
+		case "/service.Service/Stream":
+			for i := 0; i < 2; i++ {
+				if err := stream.MsgSend(&in, stringEncoding{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return stream.MsgSend(&in, stringEncoding{})
+		}
+	}), opts)
+
+	c1, c2 := net.Pipe()
+	ctx.Run(func(ctx context.Context) { _ = drpcserver.New(handler).ServeOne(ctx, c1) })
+	conn := NewConn(drpcconn.New(c2), opts)
+	defer func() { _ = conn.Close() }()
+
+	{ // unary rpcs are traced across the connection
+		in, out := "hello", ""
+		assert.NoError(t, conn.Invoke(ctx, "/service.Service/Unary", stringEncoding{}, &in, &out))
+		assert.Equal(t, out, "hello")
+	}
+
+	{ // errors record the code
+		in, out := "hello", ""
+		assert.Error(t, conn.Invoke(ctx, "/service.Service/Fail", stringEncoding{}, &in, &out))
+	}
+
+	{ // streams record message events
+		stream, err := conn.NewStream(ctx, "/service.Service/Stream", stringEncoding{})
+		assert.NoError(t, err)
+
+		in, out := "hello", ""
+		assert.NoError(t, stream.MsgSend(&in, stringEncoding{}))
+		assert.NoError(t, stream.CloseSend())
+		assert.NoError(t, stream.MsgRecv(&out, stringEncoding{}))
+		assert.NoError(t, stream.MsgRecv(&out, stringEncoding{}))
+		assert.That(t, errors.Is(stream.MsgRecv(&out, stringEncoding{}), io.EOF))
+		assert.NoError(t, stream.Close())
+	}
+
+	// wait for the spans of both sides of all three rpcs to end.
+	for deadline := time.Now().Add(5 * time.Second); len(spans.Ended()) < 6; {
+		assert.That(t, time.Now().Before(deadline))
+		time.Sleep(time.Millisecond)
+	}
+
+	byName := make(map[trace.SpanKind]map[string]sdktrace.ReadOnlySpan)
+	for _, span := range spans.Ended() {
+		if byName[span.SpanKind()] == nil {
+			byName[span.SpanKind()] = make(map[string]sdktrace.ReadOnlySpan)
+		}
+		byName[span.SpanKind()][span.Name()] = span
+	}
+
+	for _, name := range []string{"service.Service/Unary", "service.Service/Fail", "service.Service/Stream"} {
+		client := byName[trace.SpanKindClient][name]
+		server := byName[trace.SpanKindServer][name]
+		assert.NotNil(t, client)
+		assert.NotNil(t, server)
+
+		// the server span is a child of the client span
+		assert.Equal(t, server.Parent().SpanID(), client.SpanContext().SpanID())
+		assert.Equal(t, <-serverTraces, client.SpanContext().TraceID())
+
+		assert.That(t, hasAttr(client.Attributes(), rpcSystemKey.String("drpc")))
+		assert.That(t, hasAttr(client.Attributes(), rpcServiceKey.String("service.Service")))
+	}
+
+	fail := byName[trace.SpanKindServer]["service.Service/Fail"]
+	assert.Equal(t, fail.Status().Code, codes.Error)
+	assert.That(t, hasAttr(fail.Attributes(), rpcErrorCodeKey.Int64(5)))
+
+	stream := byName[trace.SpanKindClient]["service.Service/Stream"]
+	assert.Equal(t, stream.Status().Code, codes.Unset)
+	assert.Equal(t, len(stream.Events()), 3)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+
+	counts := make(map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				counts[m.Name] += dp.Count
+			}
+		}
+	}
+	assert.Equal(t, counts, map[string]uint64{
+		"rpc.client.duration": 3,
+		"rpc.server.duration": 3,
+	})
+}
+
+func hasAttr(attrs []attribute.KeyValue, kv attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == kv {
+			return true
+		}
+	}
+	return false
+}
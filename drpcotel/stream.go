@@ -0,0 +1,71 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"storj.io/drpc"
+)
+
+// stream wraps a drpc.Stream to record message events and the first error
+// that the rpc failed with.
+type stream struct {
+	drpc.Stream
+	ctx  context.Context
+	call *call
+
+	mu  sync.Mutex
+	err error
+}
+
+// Context returns the context of the rpc, which contains its span.
+func (s *stream) Context() context.Context { return s.ctx }
+
+// GetStream returns the wrapped stream.
+func (s *stream) GetStream() drpc.Stream { return s.Stream }
+
+// MsgSend sends the message and records an event if it was sent.
+func (s *stream) MsgSend(msg drpc.Message, enc drpc.Encoding) (err error) {
+	err = s.Stream.MsgSend(msg, enc)
+	s.record(true, err)
+	return err
+}
+
+// MsgRecv receives a message and records an event if one was received.
+func (s *stream) MsgRecv(msg drpc.Message, enc drpc.Encoding) (err error) {
+	err = s.Stream.MsgRecv(msg, enc)
+	s.record(false, err)
+	return err
+}
+
+// record records an event for the message or remembers the error if it is the
+// first one that is not the end of the stream.
+func (s *stream) record(sent bool, err error) {
+	if err == nil {
+		s.call.message(sent)
+		return
+	}
+	if errors.Is(err, io.EOF) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// error returns the first error that the rpc failed with.
+func (s *stream) error() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
@@ -54,7 +54,7 @@ func (p *poolConn[K, V]) Invoke(ctx context.Context, rpc string, enc drpc.Encodi
 		return errs.New("connection closed")
 	}
 
-	conn, ok := p.pool.Take(p.key)
+	conn, ok := p.pool.takeValid(ctx, p.key)
 	if !ok {
 		conn, err = p.dial(ctx, p.key)
 		if err != nil {
@@ -76,7 +76,7 @@ func (p *poolConn[K, V]) NewStream(ctx context.Context, rpc string, enc drpc.Enc
 		return nil, errs.New("connection closed")
 	}
 
-	conn, ok := p.pool.Take(p.key)
+	conn, ok := p.pool.takeValid(ctx, p.key)
 	if !ok {
 		conn, err = p.dial(ctx, p.key)
 		if err != nil {
@@ -12,6 +12,7 @@ type entry[K comparable, V Conn] struct {
 	key    K
 	val    V
 	exp    *time.Timer
+	put    time.Time
 	global node[K, V]
 	local  node[K, V]
 }
@@ -6,11 +6,13 @@ package drpcpool
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/zeebo/errs"
 
+	"storj.io/drpc"
 	"storj.io/drpc/drpcdebug"
 )
 
@@ -28,6 +30,20 @@ type Options struct {
 	// the Pool holds unlimited for any single key. Negative means
 	// no values for any single key.
 	KeyCapacity int
+
+	// Logger, if set, is used to log structured events like connections
+	// being evicted from the Pool.
+	Logger *slog.Logger
+
+	// Validate, if set, is called with a cached connection before it is
+	// reused. If it returns an error, the connection is closed and another
+	// is taken from the Pool or dialed. Connections cached for less than
+	// ValidateIdle are reused without being validated.
+	Validate func(ctx context.Context, conn drpc.Conn) error
+
+	// ValidateIdle is how long a connection must be cached before it is
+	// validated with Validate. Zero means connections are always validated.
+	ValidateIdle time.Duration
 }
 
 // Pool is a connection pool with key type K. It maintains a cache of connections
@@ -50,8 +66,8 @@ func New[K comparable, V Conn](opts Options) *Pool[K, V] {
 }
 
 func (p *Pool[K, V]) log(what string, cb func() string) {
-	if drpcdebug.Enabled {
-		drpcdebug.Log(func() (_, _, _ string) { return fmt.Sprintf("<pül %p>", p), what, cb() })
+	if drpcdebug.Global.Enabled("") {
+		drpcdebug.Global.Log("", func() (_, _, _ string) { return fmt.Sprintf("<pül %p>", p), what, cb() })
 	}
 }
 
@@ -105,6 +121,15 @@ func (p *Pool[K, V]) removeEntry(ent *entry[K, V]) {
 	}
 }
 
+// logEviction logs that the entry is being evicted for the reason to the
+// Logger if it is set.
+func (p *Pool[K, V]) logEviction(ent *entry[K, V], reason string) {
+	if p.opts.Logger != nil && p.opts.Logger.Enabled(context.Background(), slog.LevelDebug) {
+		p.opts.Logger.LogAttrs(context.Background(), slog.LevelDebug, "pool eviction",
+			slog.Any("key", ent.key), slog.String("reason", reason))
+	}
+}
+
 // closeEntry ensures the timer and connection are closed, returning any errors.
 func (p *Pool[K, V]) closeEntry(ent *entry[K, V]) error {
 	p.log("CLOSE", ent.String)
@@ -118,12 +143,55 @@ func (p *Pool[K, V]) closeEntry(ent *entry[K, V]) error {
 // Take acquires a value from the cache if one exists. It returns
 // the zero value for V and false if one does not.
 func (p *Pool[K, V]) Take(key K) (V, bool) {
+	ent := p.takeEntry(key)
+	if ent == nil {
+		return *new(V), false
+	}
+	return ent.val, true
+}
+
+// takeValid acquires a value from the cache like Take, except that cached
+// values are checked with the Validate option first. Values that fail
+// validation are closed and skipped, unless the context is done, in which case
+// the failure says nothing about the value and it is placed back in the cache.
+func (p *Pool[K, V]) takeValid(ctx context.Context, key K) (V, bool) {
+	for {
+		if p.opts.Validate != nil && ctx.Err() != nil {
+			return *new(V), false
+		}
+
+		ent := p.takeEntry(key)
+		if ent == nil {
+			return *new(V), false
+		}
+
+		if p.opts.Validate == nil || time.Since(ent.put) < p.opts.ValidateIdle {
+			return ent.val, true
+		}
+
+		err := p.opts.Validate(ctx, ent.val)
+		if err == nil {
+			return ent.val, true
+		} else if ctx.Err() != nil {
+			p.put(ent.key, ent.val, ent.put)
+			return *new(V), false
+		}
+
+		p.log("INVALID", ent.String)
+		p.logEviction(ent, "validation")
+		_ = ent.val.Close()
+	}
+}
+
+// takeEntry removes and returns an entry with a usable value for the key from
+// the cache if one exists. It returns nil if one does not.
+func (p *Pool[K, V]) takeEntry(key K) *entry[K, V] {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	local := p.entries[key]
 	if local == nil {
-		return *new(V), false
+		return nil
 	}
 
 	// N.B. this loop depends on the fact that removing an entry from
@@ -145,15 +213,21 @@ func (p *Pool[K, V]) Take(key K) (V, bool) {
 		}
 
 		p.log("TAKEN", ent.String)
-		return ent.val, true
+		return ent
 	}
 
-	return *new(V), false
+	return nil
 }
 
 // Put places the connection in to the cache with the provided key, ensuring
 // that the size limits the Pool is configured with are respected.
 func (p *Pool[K, V]) Put(key K, val V) {
+	p.put(key, val, time.Now())
+}
+
+// put places the connection in to the cache like Put, recording that it was
+// placed there at the provided time.
+func (p *Pool[K, V]) put(key K, val V, put time.Time) {
 	if p.opts.Capacity < 0 || p.opts.KeyCapacity < 0 {
 		_ = val.Close()
 		return
@@ -173,6 +247,7 @@ func (p *Pool[K, V]) Put(key K, val V) {
 	for p.opts.KeyCapacity != 0 && local.count >= p.opts.KeyCapacity {
 		ent := local.head
 
+		p.logEviction(ent, "key capacity")
 		_ = p.closeEntry(ent)
 
 		local.removeEntry(ent, (*entry[K, V]).localList)
@@ -183,6 +258,7 @@ func (p *Pool[K, V]) Put(key K, val V) {
 		ent := p.order.head
 		local := p.entries[ent.key]
 
+		p.logEviction(ent, "capacity")
 		_ = p.closeEntry(ent)
 
 		local.removeEntry(ent, (*entry[K, V]).localList)
@@ -193,13 +269,14 @@ func (p *Pool[K, V]) Put(key K, val V) {
 		}
 	}
 
-	ent := &entry[K, V]{key: key, val: val}
+	ent := &entry[K, V]{key: key, val: val, put: put}
 	local.appendEntry(ent, (*entry[K, V]).localList)
 	p.order.appendEntry(ent, (*entry[K, V]).globalList)
 	p.log("PUT", ent.String)
 
 	if p.opts.Expiration > 0 {
 		ent.exp = time.AfterFunc(p.opts.Expiration, func() {
+			p.logEviction(ent, "expiration")
 			_ = val.Close()
 			p.removeEntry(ent)
 		})
@@ -4,11 +4,15 @@
 package drpcpool
 
 import (
+	"bytes"
 	"context"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/zeebo/assert"
+	"github.com/zeebo/errs"
 
 	"storj.io/drpc"
 	"storj.io/drpc/drpctest"
@@ -154,6 +158,89 @@ func TestPool_Stale(t *testing.T) {
 	assert.Equal(t, calls, 2)
 }
 
+// TestPool_Validate checks that cached connections that fail validation are
+// closed and not reused.
+func TestPool_Validate(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var healthy bool
+	calls, validated := 0, 0
+	pool := New[string, Conn](Options{
+		Validate: func(ctx context.Context, conn drpc.Conn) error {
+			validated++
+			if !healthy {
+				return errs.New("unhealthy")
+			}
+			return nil
+		},
+	})
+	defer func() { _ = pool.Close() }()
+
+	closed := make(chan string, 1)
+	conn := pool.Get(ctx, "key", func(ctx context.Context, key string) (Conn, error) {
+		calls++
+		return &callbackConn{CloseFn: func() error { closed <- key; return nil }}, nil
+	})
+
+	// the first invoke dials without validating
+	invoke(ctx, conn)
+	assert.Equal(t, calls, 1)
+	assert.Equal(t, validated, 0)
+
+	// the cached conn fails validation so it is closed and another is dialed
+	invoke(ctx, conn)
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, validated, 1)
+	assert.Equal(t, <-closed, "key")
+
+	// the cached conn passes validation so it is reused
+	healthy = true
+	invoke(ctx, conn)
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, validated, 2)
+}
+
+// TestPool_ValidateCanceled checks that cached connections are not closed
+// because of validation failures caused by the context being done.
+func TestPool_ValidateCanceled(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	validated := 0
+	pool := New[string, Conn](Options{
+		Validate: func(ctx context.Context, conn drpc.Conn) error {
+			validated++
+			return ctx.Err()
+		},
+	})
+	defer func() { _ = pool.Close() }()
+
+	pool.Put("key", &callbackConn{CloseFn: func() error { t.Error("closed"); return nil }})
+
+	// a context that is already done does not validate or take the conn.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, ok := pool.takeValid(canceled, "key")
+	assert.That(t, !ok)
+	assert.Equal(t, validated, 0)
+
+	// a context that is done during validation places the conn back.
+	canceling, stop := context.WithCancel(ctx)
+	defer stop()
+	pool.opts.Validate = func(ctx context.Context, conn drpc.Conn) error {
+		validated++
+		stop()
+		return ctx.Err()
+	}
+	_, ok = pool.takeValid(canceling, "key")
+	assert.That(t, !ok)
+	assert.Equal(t, validated, 1)
+
+	_, ok = pool.Take("key")
+	assert.That(t, ok)
+}
+
 // TestPool_Capacity checks that total capacity limits are enforced.
 func TestPool_Capacity(t *testing.T) {
 	ctx := drpctest.NewTracker(t)
@@ -178,6 +265,26 @@ func TestPool_Capacity(t *testing.T) {
 	assert.Equal(t, <-closed, "key1")
 }
 
+// TestPool_Logger checks that evictions are logged.
+func TestPool_Logger(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var buf bytes.Buffer
+	closed := make(chan string, 1)
+	pool := New[string, Conn](Options{
+		Capacity: 1,
+		Logger:   slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	defer func() { _ = pool.Close() }()
+
+	useConn(ctx, pool, closed, "key0")
+	useConn(ctx, pool, closed, "key1")
+	assert.Equal(t, <-closed, "key0")
+
+	assert.That(t, strings.Contains(buf.String(), `msg="pool eviction" key=key0 reason=capacity`))
+}
+
 // TestPool_Capacity_Expiration checks that capacity limits are enforced
 // even if expiration is set.
 func TestPool_Capacity_Expiration(t *testing.T) {
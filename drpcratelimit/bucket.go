@@ -0,0 +1,78 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit describes the rate and burst of a token bucket.
+type Limit struct {
+	// Rate is the number of rpcs per second that are allowed. Zero or
+	// negative means unlimited.
+	Rate float64
+
+	// Burst is the maximum number of rpcs that may happen at once. If it
+	// is less than one, one is used.
+	Burst int
+}
+
+// unlimited returns true if the limit does not restrict anything.
+func (l Limit) unlimited() bool { return l.Rate <= 0 }
+
+// burst returns the maximum number of tokens in a bucket for the limit.
+func (l Limit) burst() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// bucket is a token bucket. It is created full.
+type bucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l Limit, now time.Time) *bucket {
+	return &bucket{tokens: l.burst(), last: now}
+}
+
+// take attempts to remove a token from the bucket. If there are not enough
+// tokens, it returns false and how long until a token will be available.
+func (b *bucket) take(l Limit, now time.Time) (ok bool, wait time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refillLocked(l, now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait = time.Duration(math.Ceil((1 - b.tokens) / l.Rate * float64(time.Second)))
+	return false, wait
+}
+
+// full returns true if the bucket has refilled completely by the given time.
+func (b *bucket) full(l Limit, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refillLocked(l, now)
+	return b.tokens >= l.burst()
+}
+
+// refillLocked adds any tokens that have accumulated since the last refill.
+// It must be called with the mutex held.
+func (b *bucket) refillLocked(l Limit, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(l.burst(), b.tokens+elapsed.Seconds()*l.Rate)
+		b.last = now
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcratelimit provides a drpc.Handler that rate limits rpcs.
+//
+// Limits are enforced with token buckets that can be configured per rpc and
+// accounted per key, where the key is derived from the stream, like from some
+// metadata or the address of the remote peer. Rejected rpcs return an error
+// with the drpcerr.ResourceExhausted code and a retry after duration that
+// clients can inspect with RetryAfter.
+package drpcratelimit
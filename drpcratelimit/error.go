@@ -0,0 +1,78 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"storj.io/drpc/drpcerr"
+)
+
+// The retry duration of rate limit errors is carried across the wire in a
+// trailer at the end of the error text, as a count of nanoseconds between
+// retryAfterPrefix and retryAfterSuffix. Only the trailer is parsed by clients,
+// so the rest of the text may change freely.
+const (
+	retryAfterPrefix = " [retry-after-ns="
+	retryAfterSuffix = "]"
+)
+
+// formatRetryAfter returns the trailer carrying the retry duration.
+func formatRetryAfter(wait time.Duration) string {
+	return retryAfterPrefix + strconv.FormatInt(int64(wait), 10) + retryAfterSuffix
+}
+
+// parseRetryAfter returns the retry duration from the trailer at the end of
+// msg, if it has one.
+func parseRetryAfter(msg string) (time.Duration, bool) {
+	idx := strings.LastIndex(msg, retryAfterPrefix)
+	if idx < 0 || !strings.HasSuffix(msg, retryAfterSuffix) {
+		return 0, false
+	}
+	ns, err := strconv.ParseInt(msg[idx+len(retryAfterPrefix):len(msg)-len(retryAfterSuffix)], 10, 64)
+	if err != nil || ns < 0 {
+		return 0, false
+	}
+	return time.Duration(ns), true
+}
+
+// limitError is returned when an rpc exceeds its limit.
+type limitError struct {
+	rpc  string
+	wait time.Duration
+}
+
+func (e *limitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s: retry after %v", e.rpc, e.wait) + formatRetryAfter(e.wait)
+}
+
+func (e *limitError) Code() uint64              { return drpcerr.ResourceExhausted }
+func (e *limitError) RetryAfter() time.Duration { return e.wait }
+
+// RetryAfter returns how long the server asked the client to wait before
+// retrying the rpc that failed with err. It returns false if the error was not
+// caused by a rate limit. It works with both the errors returned by a Handler
+// and the errors received by clients.
+func RetryAfter(err error) (time.Duration, bool) {
+	for cur, i := err, 0; cur != nil && i < 100; i++ {
+		if v, ok := cur.(interface{ RetryAfter() time.Duration }); ok { //nolint: errorlint // custom unwrap loop
+			return v.RetryAfter(), true
+		}
+		switch v := cur.(type) { //nolint: errorlint // custom unwrap loop
+		case interface{ Cause() error }:
+			cur = v.Cause()
+		case interface{ Unwrap() error }:
+			cur = v.Unwrap()
+		default:
+			cur = nil
+		}
+	}
+	if err == nil || drpcerr.Code(err) != drpcerr.ResourceExhausted {
+		return 0, false
+	}
+	return parseRetryAfter(err.Error())
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcratelimit
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcctx"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// Options controls configuration settings for a rate limiting handler.
+type Options struct {
+	// Default is the limit used for any rpc that does not have an entry in
+	// Limits. The zero value means those rpcs are unlimited.
+	Default Limit
+
+	// Limits contains the limits for specific rpcs keyed by the rpc name,
+	// like "/service.Service/Method".
+	Limits map[string]Limit
+
+	// Key returns the key that the rpc is accounted under. Every distinct
+	// key for an rpc has its own token bucket. If nil, every call to an rpc
+	// shares the same bucket.
+	Key func(ctx context.Context, rpc string) string
+
+	// IdleTimeout controls how often buckets that have refilled completely
+	// are discarded to bound the memory used by many distinct keys. If zero,
+	// a minute is used.
+	IdleTimeout time.Duration
+}
+
+// KeyMetadata returns a Key function that uses the value of the metadata key
+// sent by the client. Clients that do not send the metadata share a bucket.
+func KeyMetadata(key string) func(ctx context.Context, rpc string) string {
+	return func(ctx context.Context, rpc string) string {
+		md, _ := drpcmetadata.Get(ctx)
+		return md[key]
+	}
+}
+
+// KeyRemoteAddr is a Key function that uses the host of the remote address of
+// the transport serving the rpc, if the transport has one.
+func KeyRemoteAddr(ctx context.Context, rpc string) string {
+	tr, _ := drpcctx.Transport(ctx)
+	ra, ok := tr.(interface{ RemoteAddr() net.Addr })
+	if !ok || ra.RemoteAddr() == nil {
+		return ""
+	}
+	addr := ra.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// bucketKey identifies a token bucket.
+type bucketKey struct {
+	rpc string
+	key string
+}
+
+// Handler is a drpc.Handler that rejects rpcs that exceed their limits before
+// dispatching them to the wrapped handler.
+type Handler struct {
+	handler drpc.Handler
+	opts    Options
+	now     func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+	swept   time.Time
+}
+
+var _ drpc.Handler = (*Handler)(nil)
+
+// NewHandler returns a Handler that rate limits rpcs to the handler using the
+// provided options.
+func NewHandler(handler drpc.Handler, opts Options) *Handler {
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = time.Minute
+	}
+
+	return &Handler{
+		handler: handler,
+		opts:    opts,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// HandleRPC implements drpc.Handler. If the rpc is over its limit, an error is
+// returned without calling the wrapped handler.
+func (h *Handler) HandleRPC(stream drpc.Stream, rpc string) (err error) {
+	if ok, wait := h.take(stream.Context(), rpc); !ok {
+		return &limitError{rpc: rpc, wait: wait}
+	}
+	return h.handler.HandleRPC(stream, rpc)
+}
+
+// limit returns the limit configured for the rpc.
+func (h *Handler) limit(rpc string) Limit {
+	if l, ok := h.opts.Limits[rpc]; ok {
+		return l
+	}
+	return h.opts.Default
+}
+
+// take removes a token from the bucket for the rpc, returning false and how
+// long to wait if there are none available.
+func (h *Handler) take(ctx context.Context, rpc string) (ok bool, wait time.Duration) {
+	l := h.limit(rpc)
+	if l.unlimited() {
+		return true, 0
+	}
+
+	bk := bucketKey{rpc: rpc}
+	if h.opts.Key != nil {
+		bk.key = h.opts.Key(ctx, rpc)
+	}
+
+	now := h.now()
+	return h.getBucket(bk, l, now).take(l, now)
+}
+
+// getBucket returns the bucket for the key, creating it if necessary. It also
+// periodically discards any idle buckets.
+func (h *Handler) getBucket(bk bucketKey, l Limit, now time.Time) *bucket {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if now.Sub(h.swept) > h.opts.IdleTimeout {
+		h.sweepLocked(now)
+	}
+
+	b, ok := h.buckets[bk]
+	if !ok {
+		b = newBucket(l, now)
+		h.buckets[bk] = b
+	}
+	return b
+}
+
+// sweepLocked removes any buckets that are full because a full bucket is
+// indistinguishable from a newly created one. It must be called with the
+// mutex held.
+func (h *Handler) sweepLocked(now time.Time) {
+	for bk, b := range h.buckets {
+		if b.full(h.limit(bk.rpc), now) {
+			delete(h.buckets, bk)
+		}
+	}
+	h.swept = now
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmetadata"
+	"storj.io/drpc/drpcwire"
+)
+
+type ctxStream struct {
+	drpc.Stream
+	ctx context.Context
+}
+
+func (s ctxStream) Context() context.Context { return s.ctx }
+
+type countHandler int
+
+func (c *countHandler) HandleRPC(stream drpc.Stream, rpc string) error {
+	*c++
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	var calls countHandler
+	now := time.Unix(0, 0)
+
+	h := NewHandler(&calls, Options{
+		Limits: map[string]Limit{"expensive": {Rate: 2, Burst: 2}},
+		Key:    KeyMetadata("user"),
+	})
+	h.now = func() time.Time { return now }
+
+	alice := ctxStream{ctx: drpcmetadata.Add(context.Background(), "user", "alice")}
+	bob := ctxStream{ctx: drpcmetadata.Add(context.Background(), "user", "bob")}
+
+	// cheap rpcs are not limited.
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, h.HandleRPC(alice, "cheap"))
+	}
+
+	// the burst is allowed and then rpcs are rejected.
+	assert.NoError(t, h.HandleRPC(alice, "expensive"))
+	assert.NoError(t, h.HandleRPC(alice, "expensive"))
+	err := h.HandleRPC(alice, "expensive")
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.ResourceExhausted)
+
+	wait, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, wait, 500*time.Millisecond)
+
+	// other keys have their own buckets.
+	assert.NoError(t, h.HandleRPC(bob, "expensive"))
+
+	// tokens are refilled over time.
+	now = now.Add(wait)
+	assert.NoError(t, h.HandleRPC(alice, "expensive"))
+
+	assert.Equal(t, int(calls), 14)
+}
+
+func TestHandler_Sweep(t *testing.T) {
+	var calls countHandler
+	now := time.Unix(0, 0)
+
+	h := NewHandler(&calls, Options{
+		Default:     Limit{Rate: 1},
+		Key:         KeyMetadata("user"),
+		IdleTimeout: time.Second,
+	})
+	h.now = func() time.Time { return now }
+
+	for _, user := range []string{"a", "b", "c"} {
+		stream := ctxStream{ctx: drpcmetadata.Add(context.Background(), "user", user)}
+		assert.NoError(t, h.HandleRPC(stream, "rpc"))
+	}
+	assert.Equal(t, len(h.buckets), 3)
+
+	now = now.Add(2 * time.Second)
+	assert.NoError(t, h.HandleRPC(ctxStream{ctx: context.Background()}, "rpc"))
+	assert.Equal(t, len(h.buckets), 1)
+}
+
+func TestRetryAfter_Remote(t *testing.T) {
+	for _, wait := range []time.Duration{0, time.Nanosecond, 1500 * time.Millisecond, 90 * time.Minute} {
+		sent := &limitError{rpc: "rpc", wait: wait}
+		recv := drpcwire.UnmarshalError(drpcwire.MarshalError(sent))
+
+		got, ok := RetryAfter(recv)
+		assert.True(t, ok)
+		assert.Equal(t, got, wait)
+	}
+
+	// only the trailer is parsed, so the text before it does not matter.
+	got, ok := parseRetryAfter("anything: retry after 1s" + formatRetryAfter(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, got, time.Minute)
+
+	for _, msg := range []string{
+		"retry after 1s",
+		"x" + formatRetryAfter(time.Second) + " suffix",
+		"x" + retryAfterPrefix + "-1" + retryAfterSuffix,
+		"x" + retryAfterPrefix + "1s" + retryAfterSuffix,
+	} {
+		_, ok := parseRetryAfter(msg)
+		assert.False(t, ok)
+	}
+
+	// the trailer is only trusted on errors with the ResourceExhausted code.
+	_, ok = RetryAfter(errors.New("x" + formatRetryAfter(time.Second)))
+	assert.False(t, ok)
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcreflection
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc"
+)
+
+// Files asks the reflection service on the connection for the files that
+// define the symbols and returns them, along with the files they import, in a
+// registry.
+func Files(ctx context.Context, conn drpc.Conn, symbols ...string) (*protoregistry.Files, error) {
+	client := NewDRPCReflectionClient(conn)
+
+	var set descriptorpb.FileDescriptorSet
+	seen := make(map[string]bool)
+
+	for _, symbol := range symbols {
+		resp, err := client.FileContainingSymbol(ctx, &FileContainingSymbolRequest{Symbol: symbol})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, buf := range resp.GetFileDescriptorProto() {
+			fdp := new(descriptorpb.FileDescriptorProto)
+			if err := proto.Unmarshal(buf, fdp); err != nil {
+				return nil, errs.Wrap(err)
+			}
+			if !seen[fdp.GetName()] {
+				seen[fdp.GetName()] = true
+				set.File = append(set.File, fdp)
+			}
+		}
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	return files, errs.Wrap(err)
+}
@@ -0,0 +1,14 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcreflection provides a service to discover the rpcs served by a
+// drpcmux.Mux.
+//
+// The service lists every rpc registered with the Mux along with its streaming
+// kind and, for protobuf messages, the names of its input and output types. It
+// also returns the protobuf file descriptors of registered services and
+// messages so that clients like command line tools and gateways can work
+// without local copies of the .proto files.
+package drpcreflection
+
+//go:generate ../scripts/protoc.sh reflection.proto
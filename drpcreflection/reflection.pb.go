@@ -0,0 +1,508 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v4.24.4
+// source: storj.io/drpc/drpcreflection/reflection.proto
+
+package drpcreflection
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RPC_Kind int32
+
+const (
+	RPC_UNARY         RPC_Kind = 0
+	RPC_CLIENT_STREAM RPC_Kind = 1
+	RPC_SERVER_STREAM RPC_Kind = 2
+	RPC_BIDI_STREAM   RPC_Kind = 3
+)
+
+// Enum value maps for RPC_Kind.
+var (
+	RPC_Kind_name = map[int32]string{
+		0: "UNARY",
+		1: "CLIENT_STREAM",
+		2: "SERVER_STREAM",
+		3: "BIDI_STREAM",
+	}
+	RPC_Kind_value = map[string]int32{
+		"UNARY":         0,
+		"CLIENT_STREAM": 1,
+		"SERVER_STREAM": 2,
+		"BIDI_STREAM":   3,
+	}
+)
+
+func (x RPC_Kind) Enum() *RPC_Kind {
+	p := new(RPC_Kind)
+	*p = x
+	return p
+}
+
+func (x RPC_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RPC_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_enumTypes[0].Descriptor()
+}
+
+func (RPC_Kind) Type() protoreflect.EnumType {
+	return &file_storj_io_drpc_drpcreflection_reflection_proto_enumTypes[0]
+}
+
+func (x RPC_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RPC_Kind.Descriptor instead.
+func (RPC_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ListRPCsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListRPCsRequest) Reset() {
+	*x = ListRPCsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRPCsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRPCsRequest) ProtoMessage() {}
+
+func (x *ListRPCsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRPCsRequest.ProtoReflect.Descriptor instead.
+func (*ListRPCsRequest) Descriptor() ([]byte, []int) {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP(), []int{0}
+}
+
+type ListRPCsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rpcs []*RPC `protobuf:"bytes,1,rep,name=rpcs,proto3" json:"rpcs,omitempty"`
+}
+
+func (x *ListRPCsResponse) Reset() {
+	*x = ListRPCsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRPCsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRPCsResponse) ProtoMessage() {}
+
+func (x *ListRPCsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRPCsResponse.ProtoReflect.Descriptor instead.
+func (*ListRPCsResponse) Descriptor() ([]byte, []int) {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRPCsResponse) GetRpcs() []*RPC {
+	if x != nil {
+		return x.Rpcs
+	}
+	return nil
+}
+
+// RPC describes an rpc registered with the server. The input and output types
+// are the full protobuf names of the messages and are empty if the messages
+// are not protobuf messages.
+type RPC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind       RPC_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=drpc.reflection.v1.RPC_Kind" json:"kind,omitempty"`
+	InputType  string   `protobuf:"bytes,3,opt,name=input_type,json=inputType,proto3" json:"input_type,omitempty"`
+	OutputType string   `protobuf:"bytes,4,opt,name=output_type,json=outputType,proto3" json:"output_type,omitempty"`
+}
+
+func (x *RPC) Reset() {
+	*x = RPC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPC) ProtoMessage() {}
+
+func (x *RPC) ProtoReflect() protoreflect.Message {
+	mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPC.ProtoReflect.Descriptor instead.
+func (*RPC) Descriptor() ([]byte, []int) {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RPC) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RPC) GetKind() RPC_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return RPC_UNARY
+}
+
+func (x *RPC) GetInputType() string {
+	if x != nil {
+		return x.InputType
+	}
+	return ""
+}
+
+func (x *RPC) GetOutputType() string {
+	if x != nil {
+		return x.OutputType
+	}
+	return ""
+}
+
+type FileContainingSymbolRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *FileContainingSymbolRequest) Reset() {
+	*x = FileContainingSymbolRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileContainingSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileContainingSymbolRequest) ProtoMessage() {}
+
+func (x *FileContainingSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileContainingSymbolRequest.ProtoReflect.Descriptor instead.
+func (*FileContainingSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FileContainingSymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// FileDescriptorResponse contains serialized FileDescriptorProtos, with every
+// file listed after the files it imports.
+type FileDescriptorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileDescriptorProto [][]byte `protobuf:"bytes,1,rep,name=file_descriptor_proto,json=fileDescriptorProto,proto3" json:"file_descriptor_proto,omitempty"`
+}
+
+func (x *FileDescriptorResponse) Reset() {
+	*x = FileDescriptorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDescriptorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDescriptorResponse) ProtoMessage() {}
+
+func (x *FileDescriptorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDescriptorResponse.ProtoReflect.Descriptor instead.
+func (*FileDescriptorResponse) Descriptor() ([]byte, []int) {
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FileDescriptorResponse) GetFileDescriptorProto() [][]byte {
+	if x != nil {
+		return x.FileDescriptorProto
+	}
+	return nil
+}
+
+var File_storj_io_drpc_drpcreflection_reflection_proto protoreflect.FileDescriptor
+
+var file_storj_io_drpc_drpcreflection_reflection_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x73, 0x74, 0x6f, 0x72, 0x6a, 0x2e, 0x69, 0x6f, 0x2f, 0x64, 0x72, 0x70, 0x63, 0x2f,
+	0x64, 0x72, 0x70, 0x63, 0x72, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72,
+	0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x12, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x43, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50,
+	0x43, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x72, 0x70,
+	0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x72, 0x70, 0x63, 0x2e,
+	0x72, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x50,
+	0x43, 0x52, 0x04, 0x72, 0x70, 0x63, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x03, 0x52, 0x50, 0x43, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1c, 0x2e, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x50, 0x43, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x48, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x09, 0x0a,
+	0x05, 0x55, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4c, 0x49, 0x45,
+	0x4e, 0x54, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x53,
+	0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x10, 0x02, 0x12, 0x0f,
+	0x0a, 0x0b, 0x42, 0x49, 0x44, 0x49, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x10, 0x03, 0x22,
+	0x35, 0x0a, 0x1b, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x4c, 0x0a, 0x16, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x32, 0x0a, 0x15, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x13, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0xd8, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x55, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x43, 0x73, 0x12,
+	0x23, 0x2e, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50, 0x43, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x66, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x50,
+	0x43, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x14, 0x46, 0x69,
+	0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x2f, 0x2e, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x66, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x72, 0x65, 0x66, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x1e, 0x5a, 0x1c, 0x73, 0x74, 0x6f, 0x72, 0x6a, 0x2e, 0x69, 0x6f, 0x2f, 0x64, 0x72, 0x70, 0x63,
+	0x2f, 0x64, 0x72, 0x70, 0x63, 0x72, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_storj_io_drpc_drpcreflection_reflection_proto_rawDescOnce sync.Once
+	file_storj_io_drpc_drpcreflection_reflection_proto_rawDescData = file_storj_io_drpc_drpcreflection_reflection_proto_rawDesc
+)
+
+func file_storj_io_drpc_drpcreflection_reflection_proto_rawDescGZIP() []byte {
+	file_storj_io_drpc_drpcreflection_reflection_proto_rawDescOnce.Do(func() {
+		file_storj_io_drpc_drpcreflection_reflection_proto_rawDescData = protoimpl.X.CompressGZIP(file_storj_io_drpc_drpcreflection_reflection_proto_rawDescData)
+	})
+	return file_storj_io_drpc_drpcreflection_reflection_proto_rawDescData
+}
+
+var file_storj_io_drpc_drpcreflection_reflection_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_storj_io_drpc_drpcreflection_reflection_proto_goTypes = []interface{}{
+	(RPC_Kind)(0),                       // 0: drpc.reflection.v1.RPC.Kind
+	(*ListRPCsRequest)(nil),             // 1: drpc.reflection.v1.ListRPCsRequest
+	(*ListRPCsResponse)(nil),            // 2: drpc.reflection.v1.ListRPCsResponse
+	(*RPC)(nil),                         // 3: drpc.reflection.v1.RPC
+	(*FileContainingSymbolRequest)(nil), // 4: drpc.reflection.v1.FileContainingSymbolRequest
+	(*FileDescriptorResponse)(nil),      // 5: drpc.reflection.v1.FileDescriptorResponse
+}
+var file_storj_io_drpc_drpcreflection_reflection_proto_depIdxs = []int32{
+	3, // 0: drpc.reflection.v1.ListRPCsResponse.rpcs:type_name -> drpc.reflection.v1.RPC
+	0, // 1: drpc.reflection.v1.RPC.kind:type_name -> drpc.reflection.v1.RPC.Kind
+	1, // 2: drpc.reflection.v1.Reflection.ListRPCs:input_type -> drpc.reflection.v1.ListRPCsRequest
+	4, // 3: drpc.reflection.v1.Reflection.FileContainingSymbol:input_type -> drpc.reflection.v1.FileContainingSymbolRequest
+	2, // 4: drpc.reflection.v1.Reflection.ListRPCs:output_type -> drpc.reflection.v1.ListRPCsResponse
+	5, // 5: drpc.reflection.v1.Reflection.FileContainingSymbol:output_type -> drpc.reflection.v1.FileDescriptorResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_storj_io_drpc_drpcreflection_reflection_proto_init() }
+func file_storj_io_drpc_drpcreflection_reflection_proto_init() {
+	if File_storj_io_drpc_drpcreflection_reflection_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRPCsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRPCsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RPC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileContainingSymbolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDescriptorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_storj_io_drpc_drpcreflection_reflection_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_storj_io_drpc_drpcreflection_reflection_proto_goTypes,
+		DependencyIndexes: file_storj_io_drpc_drpcreflection_reflection_proto_depIdxs,
+		EnumInfos:         file_storj_io_drpc_drpcreflection_reflection_proto_enumTypes,
+		MessageInfos:      file_storj_io_drpc_drpcreflection_reflection_proto_msgTypes,
+	}.Build()
+	File_storj_io_drpc_drpcreflection_reflection_proto = out.File
+	file_storj_io_drpc_drpcreflection_reflection_proto_rawDesc = nil
+	file_storj_io_drpc_drpcreflection_reflection_proto_goTypes = nil
+	file_storj_io_drpc_drpcreflection_reflection_proto_depIdxs = nil
+}
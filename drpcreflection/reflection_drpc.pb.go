@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: (devel)
+// source: storj.io/drpc/drpcreflection/reflection.proto
+
+package drpcreflection
+
+import (
+	context "context"
+	errors "errors"
+	protojson "google.golang.org/protobuf/encoding/protojson"
+	proto "google.golang.org/protobuf/proto"
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+)
+
+type drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto struct{}
+
+func (drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto) Marshal(msg drpc.Message) ([]byte, error) {
+	return proto.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto) MarshalAppend(buf []byte, msg drpc.Message) ([]byte, error) {
+	return proto.MarshalOptions{}.MarshalAppend(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto) Unmarshal(buf []byte, msg drpc.Message) error {
+	return proto.Unmarshal(buf, msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto) JSONMarshal(msg drpc.Message) ([]byte, error) {
+	return protojson.Marshal(msg.(proto.Message))
+}
+
+func (drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto) JSONUnmarshal(buf []byte, msg drpc.Message) error {
+	return protojson.Unmarshal(buf, msg.(proto.Message))
+}
+
+type DRPCReflectionClient interface {
+	DRPCConn() drpc.Conn
+
+	ListRPCs(ctx context.Context, in *ListRPCsRequest) (*ListRPCsResponse, error)
+	FileContainingSymbol(ctx context.Context, in *FileContainingSymbolRequest) (*FileDescriptorResponse, error)
+}
+
+type drpcReflectionClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCReflectionClient(cc drpc.Conn) DRPCReflectionClient {
+	return &drpcReflectionClient{cc}
+}
+
+func (c *drpcReflectionClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcReflectionClient) ListRPCs(ctx context.Context, in *ListRPCsRequest) (*ListRPCsResponse, error) {
+	out := new(ListRPCsResponse)
+	err := c.cc.Invoke(ctx, "/drpc.reflection.v1.Reflection/ListRPCs", drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcReflectionClient) FileContainingSymbol(ctx context.Context, in *FileContainingSymbolRequest) (*FileDescriptorResponse, error) {
+	out := new(FileDescriptorResponse)
+	err := c.cc.Invoke(ctx, "/drpc.reflection.v1.Reflection/FileContainingSymbol", drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCReflectionServer interface {
+	ListRPCs(context.Context, *ListRPCsRequest) (*ListRPCsResponse, error)
+	FileContainingSymbol(context.Context, *FileContainingSymbolRequest) (*FileDescriptorResponse, error)
+}
+
+type DRPCReflectionUnimplementedServer struct{}
+
+func (s *DRPCReflectionUnimplementedServer) ListRPCs(context.Context, *ListRPCsRequest) (*ListRPCsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCReflectionUnimplementedServer) FileContainingSymbol(context.Context, *FileContainingSymbolRequest) (*FileDescriptorResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCReflectionDescription struct{}
+
+func (DRPCReflectionDescription) NumMethods() int { return 2 }
+
+func (DRPCReflectionDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/drpc.reflection.v1.Reflection/ListRPCs", drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCReflectionServer).
+					ListRPCs(
+						ctx,
+						in1.(*ListRPCsRequest),
+					)
+			}, DRPCReflectionServer.ListRPCs, true
+	case 1:
+		return "/drpc.reflection.v1.Reflection/FileContainingSymbol", drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCReflectionServer).
+					FileContainingSymbol(
+						ctx,
+						in1.(*FileContainingSymbolRequest),
+					)
+			}, DRPCReflectionServer.FileContainingSymbol, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func (DRPCReflectionDescription) MethodInfo(n int) (drpc.MethodInfo, bool) {
+	switch n {
+	case 0:
+		return drpc.MethodInfo{
+			Service:    "drpc.reflection.v1.Reflection",
+			Method:     "ListRPCs",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(ListRPCsRequest) },
+			NewOut:     func() drpc.Message { return new(ListRPCsResponse) },
+			Descriptor: File_storj_io_drpc_drpcreflection_reflection_proto.Services().Get(0).Methods().Get(0),
+		}, true
+	case 1:
+		return drpc.MethodInfo{
+			Service:    "drpc.reflection.v1.Reflection",
+			Method:     "FileContainingSymbol",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(FileContainingSymbolRequest) },
+			NewOut:     func() drpc.Message { return new(FileDescriptorResponse) },
+			Descriptor: File_storj_io_drpc_drpcreflection_reflection_proto.Services().Get(0).Methods().Get(1),
+		}, true
+	default:
+		return drpc.MethodInfo{}, false
+	}
+}
+
+func DRPCRegisterReflection(mux drpc.Mux, impl DRPCReflectionServer) error {
+	return mux.Register(impl, DRPCReflectionDescription{})
+}
+
+type DRPCReflection_ListRPCsStream interface {
+	drpc.Stream
+	SendAndClose(*ListRPCsResponse) error
+}
+
+type drpcReflection_ListRPCsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcReflection_ListRPCsStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcReflection_ListRPCsStream) SendAndClose(m *ListRPCsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCReflection_FileContainingSymbolStream interface {
+	drpc.Stream
+	SendAndClose(*FileDescriptorResponse) error
+}
+
+type drpcReflection_FileContainingSymbolStream struct {
+	drpc.Stream
+}
+
+func (x *drpcReflection_FileContainingSymbolStream) GetStream() drpc.Stream {
+	return x.Stream
+}
+
+func (x *drpcReflection_FileContainingSymbolStream) SendAndClose(m *FileDescriptorResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_storj_io_drpc_drpcreflection_reflection_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
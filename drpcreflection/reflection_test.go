@@ -0,0 +1,81 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcreflection
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpchealth"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+)
+
+func TestListRPCs(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	conn := serveConn(t, ctx)
+	defer func() { _ = conn.Close() }()
+
+	resp, err := NewDRPCReflectionClient(conn).ListRPCs(ctx, &ListRPCsRequest{})
+	assert.NoError(t, err)
+
+	type rpc struct {
+		name    string
+		kind    RPC_Kind
+		in, out string
+	}
+	var got []rpc
+	for _, r := range resp.GetRpcs() {
+		got = append(got, rpc{r.GetName(), r.GetKind(), r.GetInputType(), r.GetOutputType()})
+	}
+
+	assert.DeepEqual(t, got, []rpc{
+		{"/drpc.health.v1.Health/Check", RPC_UNARY,
+			"drpc.health.v1.HealthCheckRequest", "drpc.health.v1.HealthCheckResponse"},
+		{"/drpc.health.v1.Health/Watch", RPC_SERVER_STREAM,
+			"drpc.health.v1.HealthCheckRequest", "drpc.health.v1.HealthCheckResponse"},
+		{"/drpc.reflection.v1.Reflection/FileContainingSymbol", RPC_UNARY,
+			"drpc.reflection.v1.FileContainingSymbolRequest", "drpc.reflection.v1.FileDescriptorResponse"},
+		{"/drpc.reflection.v1.Reflection/ListRPCs", RPC_UNARY,
+			"drpc.reflection.v1.ListRPCsRequest", "drpc.reflection.v1.ListRPCsResponse"},
+	})
+}
+
+func TestFiles(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	conn := serveConn(t, ctx)
+	defer func() { _ = conn.Close() }()
+
+	files, err := Files(ctx, conn, "drpc.health.v1.Health", "drpc.health.v1.HealthCheckRequest")
+	assert.NoError(t, err)
+	assert.Equal(t, files.NumFiles(), 1)
+
+	desc, err := files.FindDescriptorByName("drpc.health.v1.Health.Watch")
+	assert.NoError(t, err)
+	assert.Equal(t, desc.ParentFile().Path(), "storj.io/drpc/drpchealth/health.proto")
+
+	_, err = Files(ctx, conn, "unknown.Service")
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.NotFound)
+}
+
+func serveConn(t *testing.T, ctx *drpctest.Tracker) *drpcconn.Conn {
+	mux := drpcmux.New()
+	assert.NoError(t, drpchealth.DRPCRegisterHealth(mux, drpchealth.NewServer()))
+	assert.NoError(t, Register(mux))
+
+	c1, c2 := net.Pipe()
+	ctx.Run(func(ctx context.Context) { _ = drpcserver.New(mux).ServeOne(ctx, c1) })
+	return drpcconn.New(c2)
+}
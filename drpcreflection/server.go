@@ -0,0 +1,127 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcreflection
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmux"
+)
+
+// Resolver looks up protobuf descriptors by their fully qualified name.
+// *protoregistry.Files is a Resolver.
+type Resolver interface {
+	FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error)
+}
+
+// Options controls configuration settings for a Server.
+type Options struct {
+	// Files is used to find the file descriptors of symbols. If nil,
+	// protoregistry.GlobalFiles is used, which contains the files of every
+	// linked in generated protobuf package.
+	Files Resolver
+}
+
+// Server implements the reflection service for the rpcs of a drpcmux.Mux.
+type Server struct {
+	DRPCReflectionUnimplementedServer
+
+	mux  *drpcmux.Mux
+	opts Options
+}
+
+var _ DRPCReflectionServer = (*Server)(nil)
+
+// NewServer constructs a new Server for the rpcs registered with the mux.
+func NewServer(mux *drpcmux.Mux) *Server {
+	return NewServerWithOptions(mux, Options{})
+}
+
+// NewServerWithOptions constructs a new Server for the rpcs registered with the
+// mux using the provided options.
+func NewServerWithOptions(mux *drpcmux.Mux, opts Options) *Server {
+	if opts.Files == nil {
+		opts.Files = protoregistry.GlobalFiles
+	}
+	return &Server{mux: mux, opts: opts}
+}
+
+// Register registers a Server for the mux with the mux itself, so that the
+// reflection service is also listed.
+func Register(mux *drpcmux.Mux) error {
+	return DRPCRegisterReflection(mux, NewServer(mux))
+}
+
+// ListRPCs returns every rpc registered with the mux sorted by name.
+func (s *Server) ListRPCs(ctx context.Context, req *ListRPCsRequest) (*ListRPCsResponse, error) {
+	var resp ListRPCsResponse
+	for _, rpc := range s.mux.RPCs() {
+		resp.Rpcs = append(resp.Rpcs, &RPC{
+			Name:       rpc.Name,
+			Kind:       RPC_Kind(rpc.Kind), // the values of drpc.MethodKind match
+			InputType:  messageName(rpc.In),
+			OutputType: messageName(rpc.Out),
+		})
+	}
+	return &resp, nil
+}
+
+// FileContainingSymbol returns the file that defines the symbol along with
+// every file it transitively imports.
+func (s *Server) FileContainingSymbol(ctx context.Context, req *FileContainingSymbolRequest) (*FileDescriptorResponse, error) {
+	desc, err := s.opts.Files.FindDescriptorByName(protoreflect.FullName(req.GetSymbol()))
+	if err != nil {
+		return nil, drpcerr.WithCode(errs.New("unknown symbol: %q", req.GetSymbol()), drpcerr.NotFound)
+	}
+
+	var resp FileDescriptorResponse
+	if err := appendFile(&resp.FileDescriptorProto, desc.ParentFile(), make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// appendFile appends the serialized forms of the imports of the file and then
+// the file itself, skipping any that have already been seen.
+func appendFile(out *[][]byte, fd protoreflect.FileDescriptor, seen map[string]bool) error {
+	if seen[fd.Path()] {
+		return nil
+	}
+	seen[fd.Path()] = true
+
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if err := appendFile(out, imports.Get(i).FileDescriptor, seen); err != nil {
+			return err
+		}
+	}
+
+	buf, err := proto.Marshal(protodesc.ToFileDescriptorProto(fd))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	*out = append(*out, buf)
+	return nil
+}
+
+// messageName returns the fully qualified protobuf name of the message type or
+// the empty string if it is not a protobuf message.
+func messageName(typ reflect.Type) string {
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return ""
+	}
+	msg, ok := reflect.New(typ.Elem()).Interface().(protoreflect.ProtoMessage)
+	if !ok {
+		return ""
+	}
+	return string(msg.ProtoReflect().Descriptor().FullName())
+}
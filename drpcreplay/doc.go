@@ -0,0 +1,29 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcreplay records drpc conversations and replays them for
+// deterministic tests.
+//
+// A Recorder is a drpctap.Sink, usually attached with its Record method, that
+// writes every packet read from and written to a tapped transport, along with
+// when it happened, as a line of JSON. A Replayer is a drpc.Transport that
+// plays back the remote side of a recorded conversation to the code under
+// test: it sends the packets that were read by the recorded side and checks
+// that the code under test writes the packets that the recorded side wrote,
+// reporting any divergence.
+//
+// For example, a conversation recorded on the client with
+//
+//	rec := drpcreplay.NewRecorder(file)
+//	conn := drpcconn.New(rec.Record(rawConn))
+//
+// can be replayed to a client under test without a server with
+//
+//	rep := drpcreplay.NewReplayer(records)
+//	conn := drpcconn.New(rep)
+//	// ... use conn ...
+//	err := rep.Check()
+//
+// A conversation recorded on the server can likewise be replayed to a server
+// under test by passing a Replayer to ServeOne.
+package drpcreplay
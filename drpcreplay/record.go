@@ -0,0 +1,128 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcreplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpctap"
+	"storj.io/drpc/drpcwire"
+)
+
+// Error is the class of errors returned by this package.
+var Error = errs.Class("drpcreplay")
+
+// Record is a packet read or written by a recorded transport.
+type Record struct {
+	// Time is when the packet was read or written relative to the first
+	// record.
+	Time time.Duration `json:"time"`
+
+	// Write is true if the packet was written by the recorded transport and
+	// false if it was read.
+	Write bool `json:"write,omitempty"`
+
+	Stream  uint64        `json:"stream"`
+	Message uint64        `json:"message"`
+	Kind    drpcwire.Kind `json:"kind"`
+	Control bool          `json:"control,omitempty"`
+	Data    []byte        `json:"data,omitempty"`
+}
+
+// Packet returns the packet of the record.
+func (r Record) Packet() drpcwire.Packet {
+	return drpcwire.Packet{
+		Data:    r.Data,
+		ID:      drpcwire.ID{Stream: r.Stream, Message: r.Message},
+		Kind:    r.Kind,
+		Control: r.Control,
+	}
+}
+
+// ReadRecords reads all of the records written by a Recorder.
+func ReadRecords(r io.Reader) (recs []Record, err error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var rec Record
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			return recs, nil
+		} else if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+// Recorder is a drpctap.Sink that writes the packets of the tapped transport
+// as records with one line of JSON each.
+type Recorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+	err   error
+}
+
+var _ drpctap.Sink = (*Recorder)(nil)
+
+// NewRecorder returns a Recorder that writes records to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Record returns a transport that wraps tr and records every packet read from
+// and written to it with the Recorder.
+func (r *Recorder) Record(tr drpc.Transport) *drpctap.Transport {
+	return drpctap.New(tr, r)
+}
+
+// Emit writes a record for packet events. If the tapped bytes could not be
+// parsed, the error is returned by Err.
+func (r *Recorder) Emit(ev drpctap.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case r.err != nil:
+		return
+	case ev.Err != nil:
+		r.err = Error.Wrap(ev.Err)
+		return
+	case ev.Packet == nil:
+		return
+	}
+
+	if r.start.IsZero() {
+		r.start = ev.Time
+	}
+
+	buf, err := json.Marshal(Record{
+		Time:    ev.Time.Sub(r.start),
+		Write:   ev.Direction == drpctap.Write,
+		Stream:  ev.Packet.ID.Stream,
+		Message: ev.Packet.ID.Message,
+		Kind:    ev.Packet.Kind,
+		Control: ev.Packet.Control,
+		Data:    ev.Packet.Data,
+	})
+	if err == nil {
+		_, err = r.w.Write(append(buf, '\n'))
+	}
+	r.err = Error.Wrap(err)
+}
+
+// Err returns the first error encountered while recording, if any.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
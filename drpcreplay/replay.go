@@ -0,0 +1,231 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcreplay
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpctap"
+	"storj.io/drpc/drpcwire"
+)
+
+// Options controls configuration settings for a Replayer.
+type Options struct {
+	// Realtime causes the Replayer to wait for the recorded amount of time
+	// between records before sending a packet.
+	Realtime bool
+
+	// Compare reports if a packet written by the code under test matches
+	// the recorded one. If nil, the packets must be identical.
+	Compare func(want, got drpcwire.Packet) bool
+}
+
+// Replayer is a drpc.Transport that plays back the remote side of a recorded
+// conversation. Reads return the packets that were read by the recorded side,
+// each only after all of the packets written before it by the recorded side
+// have been written by the code under test. Writes are matched in order
+// against the packets written by the recorded side, and may run ahead of the
+// reads. Once every record has been played and matched, reads return io.EOF
+// as if the remote closed the connection.
+type Replayer struct {
+	opts Options
+	recs []Record
+	pars *drpctap.Parser
+
+	mu     sync.Mutex
+	cond   sync.Cond
+	rpos   int       // index of the next record to play
+	wpos   int       // index of the next record to match
+	last   time.Time // when the previous record was played or matched
+	out    []byte    // bytes of played packets that have not been read
+	divs   []string
+	closed bool
+	done   chan struct{}
+}
+
+var _ drpc.Transport = (*Replayer)(nil)
+
+// NewReplayer returns a Replayer for the records.
+func NewReplayer(recs []Record) *Replayer {
+	return NewReplayerWithOptions(recs, Options{})
+}
+
+// NewReplayerWithOptions returns a Replayer for the records using the provided
+// options.
+func NewReplayerWithOptions(recs []Record, opts Options) *Replayer {
+	if opts.Compare == nil {
+		opts.Compare = equal
+	}
+	r := &Replayer{
+		opts: opts,
+		recs: recs,
+		last: time.Now(),
+		done: make(chan struct{}),
+	}
+	r.cond.L = &r.mu
+	r.pars = drpctap.NewParser(drpctap.Write, drpctap.SinkFunc(r.written))
+	return r
+}
+
+// equal returns true if the packets are identical.
+func equal(want, got drpcwire.Packet) bool {
+	return want.ID == got.ID && want.Kind == got.Kind && want.Control == got.Control &&
+		bytes.Equal(want.Data, got.Data)
+}
+
+// Read returns the bytes of the next recorded packets that were read by the
+// recorded side, blocking until they may be sent.
+func (r *Replayer) Read(p []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		r.rpos = r.next(r.rpos, false)
+		r.wpos = r.next(r.wpos, true)
+
+		switch {
+		case len(r.out) > 0:
+			n = copy(p, r.out)
+			r.out = r.out[n:]
+			return n, nil
+
+		case r.closed || (r.rpos >= len(r.recs) && r.wpos >= len(r.recs)):
+			return 0, io.EOF
+
+		case r.rpos < r.wpos:
+			if wait := r.wait(); wait > 0 {
+				r.mu.Unlock()
+				select {
+				case <-time.After(wait):
+				case <-r.done:
+				}
+				r.mu.Lock()
+				continue
+			}
+
+			rec := r.recs[r.rpos]
+			r.out = drpcwire.AppendFrame(r.out, drpcwire.Frame{
+				Data:    rec.Data,
+				ID:      drpcwire.ID{Stream: rec.Stream, Message: rec.Message},
+				Kind:    rec.Kind,
+				Done:    true,
+				Control: rec.Control,
+			})
+			r.rpos++
+			r.last = time.Now()
+
+		default:
+			r.cond.Wait()
+		}
+	}
+}
+
+// next returns the index of the first record at or after i that was written
+// by the recorded side if write is true, or read by it otherwise.
+func (r *Replayer) next(i int, write bool) int {
+	for i < len(r.recs) && r.recs[i].Write != write {
+		i++
+	}
+	return i
+}
+
+// wait returns how long to wait before playing the next record. It must be
+// called with the mutex held.
+func (r *Replayer) wait() time.Duration {
+	if !r.opts.Realtime || r.rpos == 0 {
+		return 0
+	}
+	gap := r.recs[r.rpos].Time - r.recs[r.rpos-1].Time
+	return time.Until(r.last.Add(gap))
+}
+
+// Write parses the packets written by the code under test and matches them
+// against the records.
+func (r *Replayer) Write(p []byte) (n int, err error) {
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+
+	if closed {
+		return 0, drpc.ClosedError.New("replayer closed")
+	}
+	r.pars.Feed(p)
+	return len(p), nil
+}
+
+// written matches a packet written by the code under test against the next
+// record.
+func (r *Replayer) written(ev drpctap.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.cond.Broadcast()
+
+	r.wpos = r.next(r.wpos, true)
+
+	switch {
+	case ev.Err != nil:
+		r.divs = append(r.divs, fmt.Sprintf("record %d: invalid write: %v", r.wpos, ev.Err))
+
+	case ev.Packet == nil:
+
+	case r.wpos < len(r.recs):
+		want := r.recs[r.wpos].Packet()
+		if !r.opts.Compare(want, *ev.Packet) {
+			r.divs = append(r.divs, fmt.Sprintf("record %d: want %v got %v%s",
+				r.wpos, want, *ev.Packet, dataNote(want, *ev.Packet)))
+		}
+		r.wpos++
+		r.last = time.Now()
+
+	default:
+		r.divs = append(r.divs, fmt.Sprintf("unexpected %v", *ev.Packet))
+	}
+}
+
+// dataNote returns a note about the data of the packets if the packets are
+// otherwise identical.
+func dataNote(want, got drpcwire.Packet) string {
+	if want.String() == got.String() && !bytes.Equal(want.Data, got.Data) {
+		return " (data differs)"
+	}
+	return ""
+}
+
+// Close closes the Replayer, causing any blocked reads to return.
+func (r *Replayer) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.closed {
+		r.closed = true
+		close(r.done)
+		r.cond.Broadcast()
+	}
+	return nil
+}
+
+// Check returns an error describing every divergence between the packets
+// written by the code under test and the records, including any recorded
+// packets that have not been written yet.
+func (r *Replayer) Check() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	divs := append([]string(nil), r.divs...)
+	for i := r.wpos; i < len(r.recs); i++ {
+		if r.recs[i].Write {
+			divs = append(divs, fmt.Sprintf("record %d: missing %v", i, r.recs[i].Packet()))
+		}
+	}
+	if len(divs) == 0 {
+		return nil
+	}
+	return Error.New("%d divergences:\n\t%s", len(divs), strings.Join(divs, "\n\t"))
+}
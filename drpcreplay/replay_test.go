@@ -0,0 +1,123 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcreplay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+)
+
+func TestReplayClient(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	// record a conversation on the client with a real server.
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+
+	c1, c2 := net.Pipe()
+	ctx.Run(func(ctx context.Context) { _ = drpcserver.New(echoHandler{}).ServeOne(ctx, c1) })
+	assert.Equal(t, echo(ctx, drpcconn.New(rec.Record(c2)), "hello"), "hello")
+	assert.NoError(t, rec.Err())
+
+	recs, err := ReadRecords(&buf)
+	assert.NoError(t, err)
+
+	// replaying it to the same client code matches.
+	rep := NewReplayer(recs)
+	assert.Equal(t, echo(ctx, drpcconn.New(rep), "hello"), "hello")
+	assert.NoError(t, rep.Check())
+
+	// replaying it to different client code diverges.
+	rep = NewReplayer(recs)
+	assert.Equal(t, echo(ctx, drpcconn.New(rep), "world"), "hello")
+	err = rep.Check()
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "(data differs)"))
+}
+
+func TestReplayServer(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	// record a conversation on the server with a real client.
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+
+	c1, c2 := net.Pipe()
+	served := make(chan struct{})
+	ctx.Run(func(ctx context.Context) {
+		defer close(served)
+		_ = drpcserver.New(echoHandler{}).ServeOne(ctx, rec.Record(c1))
+	})
+	assert.Equal(t, echo(ctx, drpcconn.New(c2), "hello"), "hello")
+	<-served
+
+	recs, err := ReadRecords(&buf)
+	assert.NoError(t, err)
+
+	// replaying it to the same server code matches.
+	rep := NewReplayer(recs)
+	_ = drpcserver.New(echoHandler{}).ServeOne(ctx, rep)
+	assert.NoError(t, rep.Check())
+}
+
+// echo sends the message on a stream, reads the response until the stream is
+// done, and closes the connection.
+func echo(ctx context.Context, conn *drpcconn.Conn, msg string) (out string) {
+	defer func() { _ = conn.Close() }()
+
+	stream, err := conn.NewStream(ctx, "echo", stringEncoding{})
+	if err != nil {
+		return err.Error()
+	}
+	if err := stream.MsgSend(&msg, stringEncoding{}); err != nil {
+		return err.Error()
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err.Error()
+	}
+	for {
+		var resp string
+		if err := stream.MsgRecv(&resp, stringEncoding{}); errors.Is(err, io.EOF) {
+			return out
+		} else if err != nil {
+			return err.Error()
+		}
+		out += resp
+	}
+}
+
+type echoHandler struct{}
+
+func (echoHandler) HandleRPC(stream drpc.Stream, rpc string) error {
+	var msg string
+	if err := stream.MsgRecv(&msg, stringEncoding{}); err != nil {
+		return err
+	}
+	return stream.MsgSend(&msg, stringEncoding{})
+}
+
+type stringEncoding struct{}
+
+func (stringEncoding) Marshal(msg drpc.Message) ([]byte, error) {
+	return []byte(*msg.(*string)), nil
+}
+
+func (stringEncoding) Unmarshal(buf []byte, msg drpc.Message) error {
+	*msg.(*string) = string(buf)
+	return nil
+}
@@ -5,7 +5,9 @@ package drpcserver
 
 import (
 	"context"
+	"log/slog"
 	"net"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"storj.io/drpc"
 	"storj.io/drpc/drpccache"
 	"storj.io/drpc/drpcctx"
+	"storj.io/drpc/drpcdebug"
+	"storj.io/drpc/drpcerr"
 	"storj.io/drpc/drpcmanager"
 	"storj.io/drpc/drpcstats"
 	"storj.io/drpc/drpcstream"
@@ -30,9 +34,65 @@ type Options struct {
 	// handling individual clients. It is not called if nil.
 	Log func(error)
 
+	// Logger, if set, is used to log structured events like connections
+	// being accepted and closed. It is also passed to the managers this
+	// server creates if their options do not have a Logger.
+	Logger *slog.Logger
+
 	// CollectStats controls whether the server should collect stats on the
 	// rpcs it serves.
 	CollectStats bool
+
+	// RecoverPanics controls whether panics from the handler are recovered.
+	// A recovered panic fails the rpc with an error that has the
+	// drpcerr.Internal code and the connection continues to be served.
+	RecoverPanics bool
+
+	// OnPanic is called with the rpc, the recovered value, and the stack
+	// trace of every panic recovered due to RecoverPanics. If nil, the panic
+	// is reported to Log instead.
+	OnPanic func(rpc string, val interface{}, stack []byte)
+
+	// HandlerTimeout, if non-zero, caps how long any handler may run. When
+	// it elapses, the context of the handler's stream is canceled and the
+	// client is sent an error with the drpcerr.DeadlineExceeded code. If the
+	// handler is blocked writing at that time, the stream is canceled and the
+	// transport is closed instead, so that the handler is freed even if the
+	// client is not reading.
+	HandlerTimeout time.Duration
+
+	// HandlerTimeouts overrides HandlerTimeout for the rpcs it contains. A
+	// negative value disables the timeout for that rpc.
+	HandlerTimeouts map[string]time.Duration
+
+	// OnAccept is called by Serve with every accepted connection before it
+	// is served. If it returns an error, the connection is closed and the
+	// error is reported to Log. Otherwise, the returned context is used to
+	// serve the connection, so any values on it are visible to every rpc on
+	// the connection.
+	OnAccept func(ctx context.Context, conn net.Conn) (context.Context, error)
+
+	// OnServe is called when ServeOne starts serving a transport. The
+	// returned context is used to serve the transport, so any values on it
+	// are visible to every rpc on the transport.
+	OnServe func(ctx context.Context, tr drpc.Transport) context.Context
+
+	// OnClose is called when ServeOne is finished serving a transport with
+	// the context returned by OnServe and the error ServeOne is returning.
+	OnClose func(ctx context.Context, tr drpc.Transport, err error)
+
+	// Admission, if set, is asked to admit every rpc before it is dispatched
+	// to the handler. RPCs that are not admitted are failed with the error
+	// it returns without running the handler.
+	Admission Admission
+}
+
+// Admission decides if rpcs are allowed to run.
+type Admission interface {
+	// Admit is called before the rpc is dispatched to the handler. If it
+	// returns an error, the rpc is failed with that error. Otherwise, done is
+	// called with the result of the handler once it has returned.
+	Admit(ctx context.Context, rpc string) (done func(err error), err error)
 }
 
 // Server is an implementation of drpc.Server to serve drpc connections.
@@ -57,6 +117,10 @@ func NewWithOptions(handler drpc.Handler, opts Options) *Server {
 		handler: handler,
 	}
 
+	if s.opts.Manager.Logger == nil {
+		s.opts.Manager.Logger = s.opts.Logger
+	}
+
 	if s.opts.CollectStats {
 		drpcopts.SetManagerStatsCB(&s.opts.Manager.Internal, s.getStats)
 		s.stats = make(map[string]*drpcstats.Stats)
@@ -92,7 +156,20 @@ func (s *Server) getStats(rpc string) *drpcstats.Stats {
 
 // ServeOne serves a single set of rpcs on the provided transport.
 func (s *Server) ServeOne(ctx context.Context, tr drpc.Transport) (err error) {
-	man := drpcmanager.NewWithOptions(tr, s.opts.Manager)
+	if s.opts.OnServe != nil {
+		ctx = s.opts.OnServe(ctx, tr)
+	}
+	if s.opts.OnClose != nil {
+		defer func(ctx context.Context) { s.opts.OnClose(ctx, tr, err) }(ctx)
+	}
+
+	// allow the debug tracer to be scoped to this transport by the context.
+	mopts := s.opts.Manager
+	if tracer, ok := drpcdebug.TracerFrom(ctx); ok {
+		mopts.Tracer = tracer
+	}
+
+	man := drpcmanager.NewWithOptions(tr, mopts)
 	defer func() { err = errs.Combine(err, man.Close()) }()
 
 	cache := drpccache.New()
@@ -105,7 +182,7 @@ func (s *Server) ServeOne(ctx context.Context, tr drpc.Transport) (err error) {
 		if err != nil {
 			return errs.Wrap(err)
 		}
-		if err := s.handleRPC(stream, rpc); err != nil {
+		if err := s.handleRPC(man, stream, rpc); err != nil {
 			return errs.Wrap(err)
 		}
 	}
@@ -136,6 +213,7 @@ func (s *Server) Serve(ctx context.Context, lis net.Listener) (err error) {
 				if s.opts.Log != nil {
 					s.opts.Log(err)
 				}
+				s.logAttrs(slog.LevelWarn, "temporary accept error", slog.Any("error", err))
 
 				t := time.NewTimer(temporarySleep)
 				select {
@@ -153,7 +231,7 @@ func (s *Server) Serve(ctx context.Context, lis net.Listener) (err error) {
 
 		// TODO(jeff): connection limits?
 		tracker.Run(func(ctx context.Context) {
-			err := s.ServeOne(ctx, conn)
+			err := s.serveConn(ctx, conn)
 			if err != nil && s.opts.Log != nil {
 				s.opts.Log(err)
 			}
@@ -161,11 +239,153 @@ func (s *Server) Serve(ctx context.Context, lis net.Listener) (err error) {
 	}
 }
 
+// serveConn runs the accept hook for the connection and then serves it.
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) (err error) {
+	addr := slog.String("remote_addr", addrString(conn.RemoteAddr()))
+	s.logAttrs(slog.LevelDebug, "connection accepted", addr)
+	defer func() {
+		if err != nil {
+			s.logAttrs(slog.LevelDebug, "connection closed", addr, slog.Any("error", err))
+		} else {
+			s.logAttrs(slog.LevelDebug, "connection closed", addr)
+		}
+	}()
+
+	if s.opts.OnAccept != nil {
+		ctx, err = s.opts.OnAccept(ctx, conn)
+		if err != nil {
+			return errs.Combine(err, conn.Close())
+		}
+	}
+	return s.ServeOne(ctx, conn)
+}
+
 // handleRPC handles the rpc that has been requested by the stream.
-func (s *Server) handleRPC(stream *drpcstream.Stream, rpc string) (err error) {
-	err = s.handler.HandleRPC(stream, rpc)
+func (s *Server) handleRPC(man *drpcmanager.Manager, stream *drpcstream.Stream, rpc string) (err error) {
+	var done func(error)
+	if s.opts.Admission != nil {
+		done, err = s.opts.Admission.Admit(stream.Context(), rpc)
+		if err != nil {
+			return errs.Wrap(stream.SendError(err))
+		}
+	}
+
+	var stop func() bool
+	var fired chan struct{}
+	var timedOut bool
+	hstream := drpc.Stream(stream)
+	if timeout := s.handlerTimeout(rpc); timeout > 0 {
+		hctx, cancel := context.WithTimeout(stream.Context(), timeout)
+		defer cancel()
+
+		hstream = timeoutStream{Stream: stream, ctx: hctx}
+		fired = make(chan struct{})
+		stop = context.AfterFunc(hctx, func() {
+			defer close(fired)
+			if hctx.Err() == context.DeadlineExceeded {
+				timedOut = s.timeoutRPC(man, stream, rpc, timeout)
+			}
+		})
+	}
+
+	err = s.callHandler(hstream, rpc)
+	if stop != nil && !stop() {
+		<-fired
+		if timedOut {
+			if s.opts.CollectStats {
+				s.getStats(rpc).AddTimeout()
+			}
+			err = timeoutError(rpc, s.handlerTimeout(rpc))
+		}
+	}
+	if done != nil {
+		done(err)
+	}
 	if err != nil {
 		return errs.Wrap(stream.SendError(err))
 	}
 	return errs.Wrap(stream.CloseSend())
 }
+
+// handlerTimeout returns how long the handler for the rpc may run, or zero if
+// it has no limit.
+func (s *Server) handlerTimeout(rpc string) time.Duration {
+	if timeout, ok := s.opts.HandlerTimeouts[rpc]; ok {
+		return timeout
+	}
+	return s.opts.HandlerTimeout
+}
+
+// timeoutRPC ends the stream of an rpc whose handler has exceeded its timeout
+// and returns true if it did so. If the handler is not writing, the client is
+// sent the timeout error. Otherwise, the handler may be blocked writing to a
+// peer that is not reading, so the stream is canceled and the transport is
+// closed to free it, as the manager does for a hard cancel.
+func (s *Server) timeoutRPC(man *drpcmanager.Manager, stream *drpcstream.Stream, rpc string, timeout time.Duration) bool {
+	sent, busy, _ := stream.TrySendError(timeoutError(rpc, timeout))
+	if busy {
+		stream.Cancel(context.DeadlineExceeded)
+		_ = man.Close()
+	}
+	return sent || busy
+}
+
+// timeoutStream is a stream whose context is canceled when the handler for it
+// exceeds its timeout, without having to wait for the stream's writes.
+type timeoutStream struct {
+	*drpcstream.Stream
+	ctx context.Context
+}
+
+// Context returns the context that is canceled at the handler timeout.
+func (s timeoutStream) Context() context.Context { return s.ctx }
+
+// timeoutError returns the error sent to clients when the handler for the rpc
+// exceeds its timeout.
+func timeoutError(rpc string, timeout time.Duration) error {
+	return drpcerr.WithCode(errs.New("%s exceeded handler timeout of %v", rpc, timeout), drpcerr.DeadlineExceeded)
+}
+
+// callHandler dispatches the rpc to the handler, recovering from any panics
+// if configured to do so.
+func (s *Server) callHandler(stream drpc.Stream, rpc string) (err error) {
+	if s.opts.RecoverPanics {
+		defer func() {
+			if val := recover(); val != nil {
+				err = s.recovered(rpc, val, debug.Stack())
+			}
+		}()
+	}
+	return s.handler.HandleRPC(stream, rpc)
+}
+
+// recovered reports the recovered panic and returns the error to send to the
+// client in its place.
+func (s *Server) recovered(rpc string, val interface{}, stack []byte) error {
+	s.logAttrs(slog.LevelError, "panic recovered",
+		slog.String("rpc", rpc), slog.Any("panic", val), slog.String("stack", string(stack)))
+
+	if s.opts.OnPanic != nil {
+		s.opts.OnPanic(rpc, val, stack)
+	} else if s.opts.Log != nil {
+		s.opts.Log(errs.New("panic handling %s: %v\n%s", rpc, val, stack))
+	}
+	return drpcerr.WithCode(errs.New("internal server error"), drpcerr.Internal)
+}
+
+// logAttrs logs the event to the Logger if it is set and enabled for the
+// level.
+func (s *Server) logAttrs(level slog.Level, msg string, attrs ...slog.Attr) {
+	if s.opts.Logger != nil && s.opts.Logger.Enabled(context.Background(), level) {
+		s.opts.Logger.LogAttrs(context.Background(), level, msg, attrs...)
+	}
+}
+
+// addrString returns the string form of the address or an empty string if it
+// is nil.
+func addrString(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.String()
+}
@@ -4,12 +4,26 @@
 package drpcserver
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
 	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/zeebo/assert"
+	"github.com/zeebo/errs"
 
+	"storj.io/drpc"
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcdebug"
+	"storj.io/drpc/drpcerr"
 	"storj.io/drpc/drpctest"
+	"storj.io/drpc/drpcwire"
 )
 
 func init() { temporarySleep = 0 }
@@ -35,6 +49,357 @@ func TestServerTemporarySleep(t *testing.T) {
 	assert.NoError(t, New(nil).Serve(ctx, l))
 }
 
+func TestServerAdmission(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var handled, finished int32
+	conn := serveConn(ctx, NewWithOptions(echoHandler(&handled), Options{
+		Admission: admissionFunc(func(ctx context.Context, rpc string) (func(error), error) {
+			if rpc == "rejected" {
+				return nil, drpcerr.WithCode(errs.New("rejected"), drpcerr.Unavailable)
+			}
+			return func(error) { atomic.AddInt32(&finished, 1) }, nil
+		}),
+	}))
+	defer func() { _ = conn.Close() }()
+
+	in, out := "hello", ""
+	err := conn.Invoke(ctx, "rejected", stringEncoding{}, &in, &out)
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.Unavailable)
+	assert.Equal(t, atomic.LoadInt32(&handled), 0)
+
+	assert.NoError(t, conn.Invoke(ctx, "accepted", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "hello")
+	assert.Equal(t, atomic.LoadInt32(&handled), 1)
+
+	_ = conn.Close()
+	ctx.Close()
+	assert.Equal(t, atomic.LoadInt32(&finished), 1)
+}
+
+func TestServerRecoverPanics(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var calls int32
+	var panicked string
+	echo := echoHandler(&calls)
+
+	conn := serveConn(ctx, NewWithOptions(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		if rpc == "panic" {
+			panic("boom")
+		}
+		return echo.HandleRPC(stream, rpc)
+	}), Options{
+		RecoverPanics: true,
+		OnPanic: func(rpc string, val interface{}, stack []byte) {
+			panicked = fmt.Sprintf("%s: %v", rpc, val)
+		},
+	}))
+	defer func() { _ = conn.Close() }()
+
+	in, out := "hello", ""
+	err := conn.Invoke(ctx, "panic", stringEncoding{}, &in, &out)
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.Internal)
+	assert.Equal(t, panicked, "panic: boom")
+
+	// the connection is still usable.
+	assert.NoError(t, conn.Invoke(ctx, "echo", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "hello")
+}
+
+func TestServerConnectionHooks(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	type peerKey struct{}
+
+	var accepted int32
+	logged := make(chan error, 10)
+	closed := make(chan string, 1)
+	conns := make(chan net.Conn)
+
+	srv := NewWithOptions(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		var in string
+		if err := stream.MsgRecv(&in, stringEncoding{}); err != nil {
+			return err
+		}
+		peer, _ := stream.Context().Value(peerKey{}).(string)
+		return stream.MsgSend(&peer, stringEncoding{})
+	}), Options{
+		Log: func(err error) { logged <- err },
+		OnAccept: func(ctx context.Context, conn net.Conn) (context.Context, error) {
+			if atomic.AddInt32(&accepted, 1) == 1 {
+				return nil, errs.New("rejected")
+			}
+			return context.WithValue(ctx, peerKey{}, "peer"), nil
+		},
+		OnServe: func(ctx context.Context, tr drpc.Transport) context.Context {
+			return context.WithValue(ctx, peerKey{}, ctx.Value(peerKey{}).(string)+"-served")
+		},
+		OnClose: func(ctx context.Context, tr drpc.Transport, err error) {
+			closed <- ctx.Value(peerKey{}).(string)
+		},
+	})
+
+	ctx.Run(func(ctx context.Context) {
+		_ = srv.Serve(ctx, listener(func() (net.Conn, error) {
+			select {
+			case conn := <-conns:
+				return conn, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}))
+	})
+
+	dial := func() *drpcconn.Conn {
+		c1, c2 := net.Pipe()
+		conns <- c1
+		return drpcconn.New(c2)
+	}
+
+	// the first connection is rejected by the accept hook.
+	rejected := dial()
+	defer func() { _ = rejected.Close() }()
+	assert.Equal(t, (<-logged).Error(), "rejected")
+
+	// the second connection is served with the values from the hooks.
+	conn := dial()
+	in, out := "", ""
+	assert.NoError(t, conn.Invoke(ctx, "rpc", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "peer-served")
+
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, <-closed, "peer-served")
+}
+
+func TestServerHandlerTimeout(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	canceled := make(chan error, 1)
+	received := make(chan error, 1)
+	srv := NewWithOptions(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		var in string
+		if err := stream.MsgRecv(&in, stringEncoding{}); err != nil {
+			received <- err
+			return err
+		}
+		if rpc == "slow" {
+			<-stream.Context().Done()
+			canceled <- stream.Context().Err()
+			return nil
+		}
+		return stream.MsgSend(&in, stringEncoding{})
+	}), Options{
+		CollectStats:   true,
+		HandlerTimeout: time.Hour,
+		HandlerTimeouts: map[string]time.Duration{
+			"slow": 10 * time.Millisecond,
+			"recv": 10 * time.Millisecond,
+		},
+	})
+
+	conn := serveConn(ctx, srv)
+	defer func() { _ = conn.Close() }()
+
+	in, out := "hello", ""
+	assert.NoError(t, conn.Invoke(ctx, "fast", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "hello")
+
+	err := conn.Invoke(ctx, "slow", stringEncoding{}, &in, &out)
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.DeadlineExceeded)
+	assert.Error(t, <-canceled)
+
+	// a handler blocked receiving is freed and the client is sent the error.
+	stream, err := conn.NewStream(ctx, "recv", stringEncoding{})
+	assert.NoError(t, err)
+	err = stream.MsgRecv(&out, stringEncoding{})
+	assert.Error(t, err)
+	assert.Equal(t, drpcerr.Code(err), drpcerr.DeadlineExceeded)
+	assert.Error(t, <-received)
+
+	// the connection continues to serve rpcs.
+	assert.NoError(t, conn.Invoke(ctx, "fast", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "hello")
+
+	assert.Equal(t, srv.Stats()["slow"].Timeouts, 1)
+	assert.Equal(t, srv.Stats()["recv"].Timeouts, 1)
+	assert.Equal(t, srv.Stats()["fast"].Timeouts, 0)
+}
+
+func TestServerHandlerTimeoutBlockedWrite(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	handled := make(chan context.Context, 1)
+	sent := make(chan error, 1)
+	srv := NewWithOptions(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		handled <- stream.Context()
+		out := "hello"
+		err := stream.MsgSend(&out, stringEncoding{})
+		sent <- err
+		return err
+	}), Options{HandlerTimeout: 10 * time.Millisecond})
+
+	c1, c2 := net.Pipe()
+	defer func() { _ = c2.Close() }()
+	served := make(chan error, 1)
+	ctx.Run(func(ctx context.Context) { served <- srv.ServeOne(ctx, c1) })
+
+	// invoke the rpc but never read, so that the handler is blocked writing
+	// when the timeout fires.
+	_, err := c2.Write(drpcwire.AppendFrame(nil, drpcwire.Frame{
+		ID:   drpcwire.ID{Stream: 1, Message: 1},
+		Kind: drpcwire.KindInvoke,
+		Data: []byte("rpc"),
+		Done: true,
+	}))
+	assert.NoError(t, err)
+
+	hctx := <-handled
+	<-hctx.Done()
+	assert.Equal(t, hctx.Err(), context.DeadlineExceeded)
+
+	// the blocked write is freed by closing the transport, because the peer
+	// is not reading, so ServeOne returns instead of being stuck.
+	assert.Equal(t, <-sent, context.DeadlineExceeded)
+	assert.Error(t, <-served)
+}
+
+func TestServerLogger(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	logged := make(recordHandler, 10)
+	conns := make(chan net.Conn)
+
+	var handled int32
+	srv := NewWithOptions(echoHandler(&handled), Options{Logger: slog.New(logged)})
+	ctx.Run(func(ctx context.Context) {
+		_ = srv.Serve(ctx, listener(func() (net.Conn, error) {
+			select {
+			case conn := <-conns:
+				return conn, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}))
+	})
+
+	c1, c2 := net.Pipe()
+	conns <- c1
+	conn := drpcconn.New(c2)
+
+	in, out := "hello", ""
+	assert.NoError(t, conn.Invoke(ctx, "rpc", stringEncoding{}, &in, &out))
+	assert.NoError(t, conn.Close())
+
+	assert.Equal(t, (<-logged).Message, "connection accepted")
+	assert.Equal(t, (<-logged).Message, "connection closed")
+}
+
+func TestServerTracer(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var log lockedBuffer
+	tracer := drpcdebug.NewTracer(&log)
+	tracer.Enable("traced")
+
+	var handled int32
+	srv := NewWithOptions(echoHandler(&handled), Options{
+		OnServe: func(ctx context.Context, tr drpc.Transport) context.Context {
+			return drpcdebug.WithTracer(ctx, tracer)
+		},
+	})
+	conn := serveConn(ctx, srv)
+	defer func() { _ = conn.Close() }()
+
+	in, out := "hello", ""
+	assert.NoError(t, conn.Invoke(ctx, "untraced", stringEncoding{}, &in, &out))
+	assert.NoError(t, conn.Invoke(ctx, "traced", stringEncoding{}, &in, &out))
+	assert.NoError(t, conn.Close())
+	ctx.Close()
+
+	assert.That(t, strings.Contains(log.String(), "k:srv r:traced"))
+	assert.That(t, !strings.Contains(log.String(), "untraced"))
+
+	// the packets read and the flushes are traced for the rpc of their stream.
+	assert.That(t, strings.Contains(log.String(), "| READ   | <pkt s:2 m:1"))
+	assert.That(t, strings.Contains(log.String(), "| FLUSH  |"))
+	assert.That(t, !strings.Contains(log.String(), "<pkt s:1 "))
+}
+
+// lockedBuffer is a bytes.Buffer that is safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// recordHandler is a slog.Handler that sends every record on the channel.
+type recordHandler chan slog.Record
+
+func (h recordHandler) Enabled(context.Context, slog.Level) bool      { return true }
+func (h recordHandler) Handle(_ context.Context, r slog.Record) error { h <- r; return nil }
+func (h recordHandler) WithAttrs([]slog.Attr) slog.Handler            { return h }
+func (h recordHandler) WithGroup(string) slog.Handler                 { return h }
+
+func serveConn(ctx *drpctest.Tracker, srv *Server) *drpcconn.Conn {
+	c1, c2 := net.Pipe()
+	ctx.Run(func(ctx context.Context) { _ = srv.ServeOne(ctx, c1) })
+	return drpcconn.New(c2)
+}
+
+type stringEncoding struct{}
+
+func (stringEncoding) Marshal(msg drpc.Message) ([]byte, error) {
+	return []byte(*msg.(*string)), nil
+}
+
+func (stringEncoding) Unmarshal(buf []byte, msg drpc.Message) error {
+	*msg.(*string) = string(buf)
+	return nil
+}
+
+type handlerFunc func(stream drpc.Stream, rpc string) error
+
+func (fn handlerFunc) HandleRPC(stream drpc.Stream, rpc string) error { return fn(stream, rpc) }
+
+func echoHandler(calls *int32) drpc.Handler {
+	return handlerFunc(func(stream drpc.Stream, rpc string) error {
+		atomic.AddInt32(calls, 1)
+		var msg string
+		if err := stream.MsgRecv(&msg, stringEncoding{}); err != nil {
+			return err
+		}
+		return stream.MsgSend(&msg, stringEncoding{})
+	})
+}
+
+type admissionFunc func(ctx context.Context, rpc string) (func(error), error)
+
+func (fn admissionFunc) Admit(ctx context.Context, rpc string) (func(error), error) {
+	return fn(ctx, rpc)
+}
+
 type listener func() (net.Conn, error)
 
 func (l listener) Accept() (net.Conn, error) { return l() }
@@ -0,0 +1,80 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcstats
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+)
+
+// HistogramBuckets is the number of buckets in a Histogram.
+const HistogramBuckets = 65
+
+// Histogram counts observed values in buckets with power of two boundaries.
+// Observe is lock-free and safe to call concurrently.
+type Histogram struct {
+	// Buckets[i] counts the observed values that are at most BucketBound(i)
+	// and larger than BucketBound(i-1).
+	Buckets [HistogramBuckets]uint64
+
+	// Sum is the sum of all of the observed values.
+	Sum uint64
+}
+
+// BucketBound returns the inclusive upper bound of the values counted by the
+// bucket with index i.
+func BucketBound(i int) uint64 {
+	if i >= 64 {
+		return math.MaxUint64
+	}
+	return 1<<uint(i) - 1
+}
+
+// Observe atomically adds the value to the histogram.
+func (h *Histogram) Observe(v uint64) {
+	atomic.AddUint64(&h.Buckets[bits.Len64(v)], 1)
+	atomic.AddUint64(&h.Sum, v)
+}
+
+// Count returns the number of observed values.
+func (h *Histogram) Count() (n uint64) {
+	for i := range h.Buckets {
+		n += h.Buckets[i]
+	}
+	return n
+}
+
+// Quantile returns an upper bound on the value at quantile q, which should be
+// between 0 and 1. It returns 0 if there are no observed values.
+func (h *Histogram) Quantile(q float64) uint64 {
+	count := h.Count()
+	if count == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(q * float64(count)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen uint64
+	for i := range h.Buckets {
+		seen += h.Buckets[i]
+		if seen >= rank {
+			return BucketBound(i)
+		}
+	}
+	return math.MaxUint64
+}
+
+// AtomicClone returns a copy of the histogram that is safe to use concurrently
+// with Observe.
+func (h *Histogram) AtomicClone() (c Histogram) {
+	for i := range h.Buckets {
+		c.Buckets[i] = atomic.LoadUint64(&h.Buckets[i])
+	}
+	c.Sum = atomic.LoadUint64(&h.Sum)
+	return c
+}
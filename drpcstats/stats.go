@@ -4,13 +4,55 @@
 package drpcstats
 
 import (
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
-// Stats keeps counters of read and written bytes.
+// Stats keeps counters about the rpcs, messages and bytes handled for an rpc.
+// The Add methods are safe to call concurrently and do not take any locks
+// except when counting errors with unusually large codes.
 type Stats struct {
-	Read    uint64
-	Written uint64
+	Read     uint64
+	Written  uint64
+	Timeouts uint64
+
+	// Calls is the number of streams started for the rpc and InFlight is
+	// the number of them that have not yet finished.
+	Calls    uint64
+	InFlight int64
+
+	// MessagesRead and MessagesWritten count the messages received and sent.
+	MessagesRead    uint64
+	MessagesWritten uint64
+
+	// Latency is a histogram of the durations of finished streams in
+	// nanoseconds.
+	Latency Histogram
+
+	// ReadSizes and WrittenSizes are histograms of the sizes in bytes of the
+	// messages received and sent.
+	ReadSizes    Histogram
+	WrittenSizes Histogram
+
+	// codes is before any pointer sized fields to keep the atomically
+	// accessed fields 64 bit aligned on 32 bit platforms.
+	codes [smallCodes]uint64
+
+	// others holds a *codeCounts for codes >= smallCodes. It is an
+	// atomic.Value rather than an atomic.Pointer so that Stats can be copied
+	// without vet reporting that it copies a lock.
+	others atomic.Value
+}
+
+// smallCodes is the number of error codes that are counted without locks. It
+// covers all of the codes used by drpc and grpc.
+const smallCodes = 32
+
+// codeCounts counts errors with codes too large to have a fixed counter.
+type codeCounts struct {
+	mu     sync.Mutex
+	counts map[uint64]uint64
 }
 
 // AddRead atomically adds n bytes to the Read counter.
@@ -27,10 +69,138 @@ func (s *Stats) AddWritten(n uint64) {
 	}
 }
 
+// AddTimeout atomically increments the Timeouts counter.
+func (s *Stats) AddTimeout() {
+	if s != nil {
+		atomic.AddUint64(&s.Timeouts, 1)
+	}
+}
+
+// StartCall atomically records that a stream for the rpc has started.
+func (s *Stats) StartCall() {
+	if s != nil {
+		atomic.AddUint64(&s.Calls, 1)
+		atomic.AddInt64(&s.InFlight, 1)
+	}
+}
+
+// FinishCall atomically records that a stream for the rpc has finished after
+// running for the duration.
+func (s *Stats) FinishCall(dur time.Duration) {
+	if s != nil {
+		atomic.AddInt64(&s.InFlight, -1)
+		if dur < 0 {
+			dur = 0
+		}
+		s.Latency.Observe(uint64(dur))
+	}
+}
+
+// AddMessageRead atomically records that a message of n bytes was received.
+func (s *Stats) AddMessageRead(n uint64) {
+	if s != nil {
+		atomic.AddUint64(&s.MessagesRead, 1)
+		s.ReadSizes.Observe(n)
+	}
+}
+
+// AddMessageWritten atomically records that a message of n bytes was sent.
+func (s *Stats) AddMessageWritten(n uint64) {
+	if s != nil {
+		atomic.AddUint64(&s.MessagesWritten, 1)
+		s.WrittenSizes.Observe(n)
+	}
+}
+
+// AddError atomically records that the rpc failed with an error with the code.
+func (s *Stats) AddError(code uint64) {
+	if s == nil {
+		return
+	}
+	if code < smallCodes {
+		atomic.AddUint64(&s.codes[code], 1)
+		return
+	}
+
+	others := s.loadOthers()
+	if others == nil {
+		others = &codeCounts{counts: make(map[uint64]uint64)}
+		if !s.others.CompareAndSwap(nil, others) {
+			others = s.loadOthers()
+		}
+	}
+
+	others.mu.Lock()
+	others.counts[code]++
+	others.mu.Unlock()
+}
+
+// loadOthers returns the counts for large error codes if any have been added.
+func (s *Stats) loadOthers() *codeCounts {
+	others, _ := s.others.Load().(*codeCounts)
+	return others
+}
+
+// Errors returns the number of rpcs that failed by their drpcerr code, using
+// zero for errors without a code. It returns nil if no rpcs have failed and is
+// safe to call concurrently with Add methods.
+func (s *Stats) Errors() map[uint64]uint64 {
+	var errors map[uint64]uint64
+	add := func(code, n uint64) {
+		if errors == nil {
+			errors = make(map[uint64]uint64)
+		}
+		errors[code] = n
+	}
+
+	for code := range s.codes {
+		if n := atomic.LoadUint64(&s.codes[code]); n > 0 {
+			add(uint64(code), n)
+		}
+	}
+
+	if others := s.loadOthers(); others != nil {
+		others.mu.Lock()
+		for code, n := range others.counts {
+			add(code, n)
+		}
+		others.mu.Unlock()
+	}
+
+	return errors
+}
+
 // AtomicClone returns a copy of the stats that is safe to use concurrently with Add methods.
 func (s *Stats) AtomicClone() Stats {
-	return Stats{
-		Read:    atomic.LoadUint64(&s.Read),
-		Written: atomic.LoadUint64(&s.Written),
+	c := Stats{
+		Read:     atomic.LoadUint64(&s.Read),
+		Written:  atomic.LoadUint64(&s.Written),
+		Timeouts: atomic.LoadUint64(&s.Timeouts),
+
+		Calls:    atomic.LoadUint64(&s.Calls),
+		InFlight: atomic.LoadInt64(&s.InFlight),
+
+		MessagesRead:    atomic.LoadUint64(&s.MessagesRead),
+		MessagesWritten: atomic.LoadUint64(&s.MessagesWritten),
+
+		Latency:      s.Latency.AtomicClone(),
+		ReadSizes:    s.ReadSizes.AtomicClone(),
+		WrittenSizes: s.WrittenSizes.AtomicClone(),
+	}
+
+	for code := range s.codes {
+		c.codes[code] = atomic.LoadUint64(&s.codes[code])
 	}
+
+	if others := s.loadOthers(); others != nil {
+		clone := &codeCounts{counts: make(map[uint64]uint64)}
+		others.mu.Lock()
+		for code, n := range others.counts {
+			clone.counts[code] = n
+		}
+		others.mu.Unlock()
+		c.others.Store(clone)
+	}
+
+	return c
 }
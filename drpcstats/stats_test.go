@@ -0,0 +1,75 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcstats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+)
+
+func TestStats(t *testing.T) {
+	var s Stats
+
+	s.StartCall()
+	s.StartCall()
+	s.AddMessageRead(10)
+	s.AddMessageWritten(100)
+	s.AddError(5)
+	s.AddError(5)
+	s.AddError(1000)
+	s.FinishCall(time.Millisecond)
+
+	c := s.AtomicClone()
+	assert.Equal(t, c.Calls, 2)
+	assert.Equal(t, c.InFlight, 1)
+	assert.Equal(t, c.MessagesRead, 1)
+	assert.Equal(t, c.MessagesWritten, 1)
+	assert.DeepEqual(t, c.Errors(), map[uint64]uint64{5: 2, 1000: 1})
+	assert.DeepEqual(t, s.Errors(), map[uint64]uint64{5: 2, 1000: 1})
+	assert.Equal(t, c.Latency.Count(), 1)
+	assert.Equal(t, c.Latency.Sum, uint64(time.Millisecond))
+	assert.Equal(t, c.ReadSizes.Sum, 10)
+	assert.Equal(t, c.WrittenSizes.Sum, 100)
+
+	// clones do not share the counts of large error codes.
+	s.AddError(1000)
+	assert.DeepEqual(t, c.Errors(), map[uint64]uint64{5: 2, 1000: 1})
+
+	// stats are comparable.
+	var z Stats
+	assert.That(t, z == Stats{})
+	assert.Nil(t, z.Errors())
+
+	// nil stats are ignored
+	var n *Stats
+	n.StartCall()
+	n.AddError(1000)
+	n.FinishCall(time.Second)
+}
+
+func TestHistogram(t *testing.T) {
+	var h Histogram
+	assert.Equal(t, h.Quantile(0.5), 0)
+
+	for v := uint64(0); v < 100; v++ {
+		h.Observe(v)
+	}
+	h.Observe(math.MaxUint64)
+
+	assert.Equal(t, h.Count(), 101)
+	assert.Equal(t, h.Buckets[0], 1)       // 0
+	assert.Equal(t, h.Buckets[1], 1)       // 1
+	assert.Equal(t, h.Buckets[7], 100-64)  // 64..99
+	assert.Equal(t, h.Buckets[64], 1)      // max
+	assert.Equal(t, h.Quantile(0.5), 63)   // the 51st value is 50
+	assert.Equal(t, h.Quantile(0.99), 127) // the 100th value is 99
+	assert.Equal(t, h.Quantile(1), uint64(math.MaxUint64))
+
+	assert.Equal(t, BucketBound(0), 0)
+	assert.Equal(t, BucketBound(7), 127)
+	assert.Equal(t, BucketBound(64), uint64(math.MaxUint64))
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"runtime/trace"
 	"sync"
+	"time"
 
 	"github.com/zeebo/errs"
 
@@ -16,7 +17,9 @@ import (
 	"storj.io/drpc/drpcctx"
 	"storj.io/drpc/drpcdebug"
 	"storj.io/drpc/drpcenc"
+	"storj.io/drpc/drpcerr"
 	"storj.io/drpc/drpcsignal"
+	"storj.io/drpc/drpcstats"
 	"storj.io/drpc/drpcwire"
 	"storj.io/drpc/internal/drpcopts"
 )
@@ -48,6 +51,10 @@ type Stream struct {
 	fin  chan<- struct{}
 	task *trace.Task
 
+	stats  *drpcstats.Stats
+	start  time.Time
+	tracer *drpcdebug.Tracer
+
 	write inspectMutex
 	read  inspectMutex
 	flush sync.Once
@@ -105,6 +112,17 @@ func NewWithOptions(ctx context.Context, sid uint64, wr *drpcwire.Writer, opts O
 	// initialize the packet buffer
 	s.pbuf.init()
 
+	// use the global tracer if the manager did not provide one
+	if s.tracer = drpcopts.GetStreamTracer(&opts.Internal); s.tracer == nil {
+		s.tracer = drpcdebug.Global
+	}
+
+	// record the start of the call if stats are being collected
+	if s.stats = drpcopts.GetStreamStats(&opts.Internal); s.stats != nil {
+		s.stats.StartCall()
+		s.start = time.Now()
+	}
+
 	return s
 }
 
@@ -116,8 +134,8 @@ func (s *Stream) String() string {
 }
 
 func (s *Stream) log(what string, cb func() string) {
-	if drpcdebug.Enabled {
-		drpcdebug.Log(func() (_, _, _ string) { return s.String(), what, cb() })
+	if rpc := drpcopts.GetStreamRPC(&s.opts.Internal); s.tracer.Enabled(rpc) {
+		s.tracer.Log(rpc, func() (_, _, _ string) { return s.String(), what, cb() })
 	}
 	if s.task != nil {
 		trace.Log(&s.ctx, what, cb())
@@ -219,7 +237,7 @@ func (s *Stream) HandlePacket(pkt drpcwire.Packet) (err error) {
 		return nil
 	}
 
-	drpcopts.GetStreamStats(&s.opts.Internal).AddRead(uint64(len(pkt.Data)))
+	s.stats.AddRead(uint64(len(pkt.Data)))
 
 	if s.sigs.term.IsSet() {
 		return nil
@@ -228,6 +246,7 @@ func (s *Stream) HandlePacket(pkt drpcwire.Packet) (err error) {
 	s.log("HANDLE", pkt.String)
 
 	if pkt.Kind == drpcwire.KindMessage {
+		s.stats.AddMessageRead(uint64(len(pkt.Data)))
 		s.pbuf.Put(pkt.Data)
 		return nil
 	}
@@ -243,6 +262,7 @@ func (s *Stream) HandlePacket(pkt drpcwire.Packet) (err error) {
 
 	case drpcwire.KindError:
 		err := drpcwire.UnmarshalError(pkt.Data)
+		s.stats.AddError(drpcerr.Code(err))
 		s.sigs.send.Set(io.EOF) // in this state, gRPC returns io.EOF on send.
 		s.terminate(err)
 		return nil
@@ -290,6 +310,9 @@ func (s *Stream) checkFinished() {
 		if s.sigs.fin.Set(nil) {
 			s.log("FIN", func() string { return "" })
 			s.ctx.sig.Set(context.Canceled)
+			if s.stats != nil {
+				s.stats.FinishCall(time.Since(s.start))
+			}
 			if s.fin != nil {
 				s.fin <- struct{}{}
 			}
@@ -326,7 +349,7 @@ func (s *Stream) sendPacketLocked(kind drpcwire.Kind, control bool, data []byte)
 	fr.Control = control
 	fr.Done = true
 
-	drpcopts.GetStreamStats(&s.opts.Internal).AddWritten(uint64(len(data)))
+	s.stats.AddWritten(uint64(len(data)))
 	s.log("SEND", fr.String)
 
 	if err := s.wr.WriteFrame(fr); err != nil {
@@ -372,6 +395,10 @@ func (s *Stream) RawWrite(kind drpcwire.Kind, data []byte) (err error) {
 // rawWriteLocked does the body of RawWrite assuming the caller is holding the
 // appropriate locks.
 func (s *Stream) rawWriteLocked(kind drpcwire.Kind, data []byte) (err error) {
+	if kind == drpcwire.KindMessage {
+		s.stats.AddMessageWritten(uint64(len(data)))
+	}
+
 	fr := s.newFrameLocked(kind)
 	n := s.opts.SplitSize
 
@@ -386,7 +413,7 @@ func (s *Stream) rawWriteLocked(kind drpcwire.Kind, data []byte) (err error) {
 		fr.Data, data = drpcwire.SplitData(data, n)
 		fr.Done = len(data) == 0
 
-		drpcopts.GetStreamStats(&s.opts.Internal).AddWritten(uint64(len(fr.Data)))
+		s.stats.AddWritten(uint64(len(fr.Data)))
 		s.log("SEND", fr.String)
 
 		if err := s.wr.WriteFrame(fr); err != nil {
@@ -537,6 +564,7 @@ func (s *Stream) SendError(serr error) (err error) {
 	s.write.Lock()
 	defer s.write.Unlock()
 
+	s.stats.AddError(drpcerr.Code(serr))
 	s.sigs.send.Set(io.EOF) // in this state, gRPC returns io.EOF on send.
 	s.terminate(termError)
 	s.mu.Unlock()
@@ -544,6 +572,36 @@ func (s *Stream) SendError(serr error) (err error) {
 	return s.checkCancelError(s.sendPacketLocked(drpcwire.KindError, false, drpcwire.MarshalError(serr)))
 }
 
+// TrySendError is like SendError except that it does not wait for writes that
+// are already happening. It returns true for busy if writes are blocked, in
+// which case nothing is sent, and true for sent if the error was sent.
+func (s *Stream) TrySendError(serr error) (sent, busy bool, err error) {
+	s.log("CALL", func() string { return fmt.Sprintf("TrySendError(%v)", serr) })
+
+	if !s.mu.TryLock() { // if we can't inspect if writes are happening, we're busy.
+		return false, true, nil
+	}
+
+	if !s.write.TryLock() { // if writes are happening, then we're busy.
+		s.mu.Unlock()
+		return false, true, nil
+	}
+	defer s.checkFinished()
+	defer s.write.Unlock()
+
+	if s.sigs.term.IsSet() {
+		s.mu.Unlock()
+		return false, false, nil
+	}
+
+	s.stats.AddError(drpcerr.Code(serr))
+	s.sigs.send.Set(io.EOF) // in this state, gRPC returns io.EOF on send.
+	s.terminate(termError)
+	s.mu.Unlock()
+
+	return true, false, s.checkCancelError(s.sendPacketLocked(drpcwire.KindError, false, drpcwire.MarshalError(serr)))
+}
+
 // SendCancel transitions the stream into the canceled state with
 // context.Canceled and sends a cancel error to the remote side for a soft
 // cancel. It is a no-op if the stream is already terminated. It returns true
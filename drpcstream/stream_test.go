@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"runtime"
 	"testing"
 
 	"github.com/zeebo/assert"
@@ -357,3 +358,43 @@ func TestStream_SendCancelWhileConcurrentWriteAndCloseBlocked(t *testing.T) {
 	assert.NoError(t, err)
 	assert.That(t, busy)
 }
+
+func TestStream_TrySendError(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	pr, pw := io.Pipe()
+	defer func() { _ = pr.Close() }()
+	defer func() { _ = pw.Close() }()
+
+	st := New(ctx, 0, drpcwire.NewWriter(pw, 0))
+
+	// launch a goroutine to send a message
+	ctx.Run(func(ctx context.Context) { _ = st.MsgSend([]byte("write"), byteEncoding{}) })
+
+	// read just 1 byte from the pipe to ensure that the send has started
+	_, err := pr.Read(make([]byte, 1))
+	assert.NoError(t, err)
+
+	// the error is not sent while the message is blocked.
+	sent, busy, err := st.TrySendError(errs.New("test"))
+	assert.NoError(t, err)
+	assert.That(t, !sent && busy)
+	assert.That(t, !st.IsTerminated())
+
+	// once the message is written, the error is sent.
+	ctx.Run(func(ctx context.Context) { _, _ = io.Copy(io.Discard, pr) })
+	for !st.write.Unlocked() {
+		runtime.Gosched()
+	}
+
+	sent, busy, err = st.TrySendError(errs.New("test"))
+	assert.NoError(t, err)
+	assert.That(t, sent && !busy)
+	assert.That(t, st.IsTerminated())
+
+	// nothing is sent once the stream is terminated.
+	sent, busy, err = st.TrySendError(errs.New("test"))
+	assert.NoError(t, err)
+	assert.That(t, !sent && !busy)
+}
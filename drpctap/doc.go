@@ -0,0 +1,11 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpctap provides a wiretap for live drpc connections.
+//
+// A Transport wraps a drpc.Transport and parses the bytes read from and
+// written to it with drpcwire.ParseFrame, emitting every frame and reassembled
+// packet to a Sink. Clients wrap the transport they pass to drpcconn and
+// servers wrap the listener they pass to drpcserver, so neither has to be
+// changed. Which streams are reported can be sampled per rpc.
+package drpctap
@@ -0,0 +1,49 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpctap
+
+import (
+	"net"
+)
+
+// Listener is a net.Listener that wraps every accepted connection with a
+// Transport so that servers can be tapped without changing them.
+type Listener struct {
+	net.Listener
+
+	sink Sink
+	opts Options
+}
+
+// NewListener returns a Listener that wraps the connections accepted by lis,
+// emitting their events to sink.
+func NewListener(lis net.Listener, sink Sink, opts Options) *Listener {
+	return &Listener{Listener: lis, sink: sink, opts: opts}
+}
+
+// Accept waits for and returns the next connection wrapped with a Transport.
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn, l.sink, l.opts), nil
+}
+
+// Conn is a net.Conn that is tapped by a Transport.
+type Conn struct {
+	net.Conn
+	tr *Transport
+}
+
+// NewConn returns a Conn that wraps conn and emits its events to sink.
+func NewConn(conn net.Conn, sink Sink, opts Options) *Conn {
+	return &Conn{Conn: conn, tr: NewWithOptions(conn, sink, opts)}
+}
+
+// Read reads from the wrapped connection and parses the bytes read.
+func (c *Conn) Read(p []byte) (int, error) { return c.tr.Read(p) }
+
+// Write parses the bytes and then writes them to the wrapped connection.
+func (c *Conn) Write(p []byte) (int, error) { return c.tr.Write(p) }
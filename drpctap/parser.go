@@ -0,0 +1,159 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpctap
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcmigrate"
+	"storj.io/drpc/drpcwire"
+)
+
+// Parser parses the bytes flowing in one direction of a connection into frames
+// and packets, emitting the same events that a Transport would. It can be used
+// to inspect bytes that are not read from or written to a drpc.Transport.
+type Parser struct {
+	t   *Transport
+	dir Direction
+}
+
+// NewParser returns a Parser for bytes flowing in the direction that emits
+// events to sink.
+func NewParser(dir Direction, sink Sink) *Parser {
+	return NewParserWithOptions(dir, sink, Options{})
+}
+
+// NewParserWithOptions returns a Parser for bytes flowing in the direction
+// that emits events to sink using the provided options.
+func NewParserWithOptions(dir Direction, sink Sink, opts Options) *Parser {
+	return &Parser{t: NewWithOptions(nil, sink, opts), dir: dir}
+}
+
+// Feed parses the bytes, emitting an event for every frame and packet that
+// they complete. It is safe to call concurrently, but the bytes must be fed in
+// the order that they flowed.
+func (p *Parser) Feed(data []byte) {
+	if p.dir == Read {
+		p.t.read.feed(data)
+	} else {
+		p.t.write.feed(data)
+	}
+}
+
+// parser parses the bytes flowing in one direction of a Transport into frames
+// and packets.
+type parser struct {
+	t   *Transport
+	dir Direction
+
+	mu     sync.Mutex
+	buf    []byte
+	start  bool // true once the start of the byte stream has been checked
+	failed bool
+	pkt    drpcwire.Packet
+	id     drpcwire.ID
+}
+
+// init prepares the parser to parse bytes for the direction of the Transport.
+func (p *parser) init(t *Transport, dir Direction) {
+	p.t, p.dir = t, dir
+}
+
+// feed parses the bytes, emitting an event for every frame and packet that
+// they complete.
+func (p *parser) feed(data []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failed {
+		return
+	}
+	p.buf = append(p.buf, data...)
+
+	// the drpcmigrate header is sent before any frames if the tap wraps the
+	// connection below a drpcmigrate.HeaderConn.
+	if !p.start {
+		header := []byte(drpcmigrate.DRPCHeader)
+		if len(p.buf) < len(header) && bytes.HasPrefix(header, p.buf) {
+			return
+		}
+		p.buf = bytes.TrimPrefix(p.buf, header)
+		p.start = true
+	}
+
+	now := time.Now()
+	rem := p.buf
+	for {
+		next, fr, ok, err := drpcwire.ParseFrame(rem)
+		if err != nil {
+			p.fail(drpc.ProtocolError.Wrap(err))
+			return
+		} else if !ok {
+			break
+		}
+		rem = next
+
+		if err := p.frame(now, fr); err != nil {
+			p.fail(err)
+			return
+		}
+	}
+
+	if len(rem) > p.t.opts.MaximumBufferSize {
+		p.fail(drpc.ProtocolError.New("data overflow"))
+		return
+	}
+	p.buf = append(p.buf[:0], rem...)
+}
+
+// frame handles a parsed frame, reassembling packets the same way as a
+// drpcwire.Reader.
+func (p *parser) frame(now time.Time, fr drpcwire.Frame) error {
+	if p.t.opts.Frames {
+		efr := fr
+		efr.Data = append([]byte(nil), fr.Data...)
+		p.t.emit(fr.ID.Stream, Event{Time: now, Direction: p.dir, Frame: &efr}, "")
+	}
+
+	switch {
+	case fr.ID.Less(p.id):
+		return drpc.ProtocolError.New("id monotonicity violation (fr:%v r:%v)", fr.ID, p.id)
+
+	case p.id != fr.ID || p.pkt.ID == drpcwire.ID{}:
+		p.id = fr.ID
+		p.pkt = drpcwire.Packet{ID: fr.ID, Kind: fr.Kind, Control: fr.Control}
+
+	case fr.Kind != p.pkt.Kind:
+		return drpc.ProtocolError.New("packet kind change (fr:%v pkt:%v)", fr.Kind, p.pkt.Kind)
+	}
+
+	p.pkt.Control = p.pkt.Control || fr.Control
+	p.pkt.Data = append(p.pkt.Data, fr.Data...)
+	if len(p.pkt.Data) > p.t.opts.MaximumBufferSize {
+		return drpc.ProtocolError.New("data overflow (len:%v)", len(p.pkt.Data))
+	}
+	if !fr.Done {
+		return nil
+	}
+
+	pkt := p.pkt
+	p.pkt = drpcwire.Packet{}
+	p.id.Message++
+
+	var invoke string
+	if pkt.Kind == drpcwire.KindInvoke {
+		invoke = string(pkt.Data)
+	}
+	p.t.emit(pkt.ID.Stream, Event{Time: now, Direction: p.dir, Packet: &pkt}, invoke)
+	return nil
+}
+
+// fail emits the error and stops parsing.
+func (p *parser) fail(err error) {
+	p.failed, p.buf, p.pkt = true, nil, drpcwire.Packet{}
+	p.t.fail(p.dir, err)
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpctap
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcwire"
+)
+
+// Direction is the direction that bytes flowed through a Transport.
+type Direction uint8
+
+const (
+	// Read is for bytes read from the wrapped transport.
+	Read Direction = iota
+
+	// Write is for bytes written to the wrapped transport.
+	Write
+)
+
+// String returns a human readable form of the Direction.
+func (d Direction) String() string {
+	switch d {
+	case Read:
+		return "read"
+	case Write:
+		return "write"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a frame or packet that flowed through a Transport, or an error
+// parsing the bytes. Exactly one of Frame, Packet or Err is set.
+type Event struct {
+	// Time is when the bytes that completed the frame or packet were read,
+	// or were about to be written.
+	Time time.Time
+
+	// Direction is the direction that the bytes flowed.
+	Direction Direction
+
+	// RPC is the rpc of the stream the frame or packet is for, if known.
+	RPC string
+
+	// Frame is set for frame events. Its data is owned by the event.
+	Frame *drpcwire.Frame
+
+	// Packet is set for packet events. Its data is owned by the event.
+	Packet *drpcwire.Packet
+
+	// Err is set if the bytes could not be parsed. No more events are
+	// emitted for the direction afterwards.
+	Err error
+}
+
+// Sink receives the events of a Transport. It is called synchronously while
+// reading and writing, so it should be fast, and it may be called
+// concurrently for the two directions.
+type Sink interface {
+	Emit(ev Event)
+}
+
+// SinkFunc is a function that implements Sink.
+type SinkFunc func(ev Event)
+
+// Emit calls the function with the event.
+func (fn SinkFunc) Emit(ev Event) { fn(ev) }
+
+// Options controls configuration settings for a Transport.
+type Options struct {
+	// Frames causes an event to be emitted for every frame in addition to
+	// the reassembled packets.
+	Frames bool
+
+	// Sample is called with the rpc of every stream to decide if the events
+	// for the stream are emitted. Events for streams whose rpc is unknown,
+	// like if the tap was attached in the middle of the stream, are sampled
+	// with the empty rpc. If nil, every stream is sampled and events are
+	// emitted as soon as they are parsed instead of being held until the rpc
+	// of their stream is known.
+	Sample func(rpc string) bool
+
+	// MaximumBufferSize is the maximum amount of data buffered for a packet
+	// in each direction. If exceeded, an error event is emitted and parsing
+	// stops for that direction. If zero, 4MiB is used.
+	MaximumBufferSize int
+}
+
+// SampleRates returns a function for Options.Sample that samples streams of
+// the rpcs in rates with the given probability, and any others with def.
+// Probabilities are between 0 and 1.
+func SampleRates(rates map[string]float64, def float64) func(rpc string) bool {
+	return func(rpc string) bool {
+		rate, ok := rates[rpc]
+		if !ok {
+			rate = def
+		}
+		return rate >= 1 || (rate > 0 && rand.Float64() < rate) //nolint: gosec // not used for security
+	}
+}
+
+// Transport is a drpc.Transport that reports the frames and packets read from
+// and written to the transport it wraps.
+type Transport struct {
+	tr   drpc.Transport
+	sink Sink
+	opts Options
+
+	mu      sync.Mutex
+	streams map[uint64]*streamState
+	latest  uint64
+
+	read  parser
+	write parser
+}
+
+// streamState keeps track of the rpc of a stream and the events that are held
+// until it is known whether the stream is sampled.
+type streamState struct {
+	rpc     string
+	decided bool
+	sampled bool
+	pending []Event
+}
+
+// maxPending is the number of events held for a stream before it is sampled
+// without knowing its rpc.
+const maxPending = 16
+
+// New returns a Transport that wraps tr and emits events to sink.
+func New(tr drpc.Transport, sink Sink) *Transport {
+	return NewWithOptions(tr, sink, Options{})
+}
+
+// NewWithOptions returns a Transport that wraps tr and emits events to sink
+// using the provided options.
+func NewWithOptions(tr drpc.Transport, sink Sink, opts Options) *Transport {
+	if opts.MaximumBufferSize == 0 {
+		opts.MaximumBufferSize = 4 << 20
+	}
+	t := &Transport{
+		tr:      tr,
+		sink:    sink,
+		opts:    opts,
+		streams: make(map[uint64]*streamState),
+	}
+	t.read.init(t, Read)
+	t.write.init(t, Write)
+	return t
+}
+
+// Transport returns the wrapped transport.
+func (t *Transport) Transport() drpc.Transport { return t.tr }
+
+// Read reads from the wrapped transport and parses the bytes read.
+func (t *Transport) Read(p []byte) (n int, err error) {
+	n, err = t.tr.Read(p)
+	if n > 0 {
+		t.read.feed(p[:n])
+	}
+	return n, err
+}
+
+// Write parses the bytes and then writes them to the wrapped transport. The
+// bytes are parsed first so that the events for them are emitted before the
+// events for any response from the remote, so events are emitted even for
+// bytes that the wrapped transport fails to write.
+func (t *Transport) Write(p []byte) (n int, err error) {
+	t.write.feed(p)
+	return t.tr.Write(p)
+}
+
+// Close closes the wrapped transport.
+func (t *Transport) Close() error { return t.tr.Close() }
+
+// emit sends the event for the stream to the sink if the stream is sampled,
+// holding it until the stream's rpc is known if necessary. The invoke argument
+// is set if the event is the Invoke packet that names the rpc of the stream.
+func (t *Transport) emit(stream uint64, ev Event, invoke string) {
+	var evs []Event
+
+	t.mu.Lock()
+	st := t.streams[stream]
+	if st == nil {
+		st = new(streamState)
+		t.streams[stream] = st
+		if stream > t.latest {
+			t.latest = stream
+			evs = t.flushOld(stream)
+		}
+	}
+
+	if invoke != "" {
+		st.rpc = invoke
+	}
+
+	// without a sampling function there is nothing to hold the events for.
+	if !st.decided && (invoke != "" || t.opts.Sample == nil) {
+		t.decide(st)
+	}
+	st.pending = append(st.pending, ev)
+	if !st.decided && len(st.pending) >= maxPending {
+		t.decide(st)
+	}
+	if st.decided {
+		evs = append(evs, t.flush(st)...)
+	}
+	t.mu.Unlock()
+
+	for _, ev := range evs {
+		t.sink.Emit(ev)
+	}
+}
+
+// flushOld decides the sampling of every stream before the new stream that is
+// still undecided, returning their held events, and forgets about streams that
+// are old enough to not receive any more events. It must be called with the
+// mutex held.
+func (t *Transport) flushOld(id uint64) (evs []Event) {
+	for oid, st := range t.streams {
+		if oid < id && !st.decided {
+			t.decide(st)
+			evs = append(evs, t.flush(st)...)
+		}
+		if oid+maxPending < id {
+			delete(t.streams, oid)
+		}
+	}
+	return evs
+}
+
+// flush returns the held events of the decided stream if it is sampled. It
+// must be called with the mutex held.
+func (t *Transport) flush(st *streamState) []Event {
+	evs := st.pending
+	st.pending = nil
+	if !st.sampled {
+		return nil
+	}
+	for i := range evs {
+		evs[i].RPC = st.rpc
+	}
+	return evs
+}
+
+// decide decides if the stream is sampled. It must be called with the mutex
+// held.
+func (t *Transport) decide(st *streamState) {
+	st.decided = true
+	st.sampled = t.opts.Sample == nil || t.opts.Sample(st.rpc)
+}
+
+// fail emits an error event for the direction.
+func (t *Transport) fail(dir Direction, err error) {
+	t.sink.Emit(Event{Time: time.Now(), Direction: dir, Err: err})
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpctap
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+	"storj.io/drpc/drpcwire"
+)
+
+func TestTransport(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var client, server recorder
+	conn := serve(ctx, &client, &server, Options{})
+	defer func() { _ = conn.Close() }()
+
+	in, out := "hello", ""
+	assert.NoError(t, conn.Invoke(ctx, "echo", stringEncoding{}, &in, &out))
+	assert.Equal(t, out, "hello")
+	assert.NoError(t, conn.Close())
+	ctx.Close()
+
+	assert.DeepEqual(t, client.get("write", 3), []string{
+		"write echo packet s:1 m:1 Invoke",
+		"write echo packet s:1 m:2 Message hello",
+		"write echo packet s:1 m:3 CloseSend",
+	})
+	assert.DeepEqual(t, client.get("read", 1), []string{
+		"read echo packet s:1 m:1 Message hello",
+	})
+	assert.DeepEqual(t, server.get("read", 2), []string{
+		"read echo packet s:1 m:1 Invoke",
+		"read echo packet s:1 m:2 Message hello",
+	})
+	assert.DeepEqual(t, server.get("write", 1), []string{
+		"write echo packet s:1 m:1 Message hello",
+	})
+}
+
+func TestTransportSample(t *testing.T) {
+	ctx := drpctest.NewTracker(t)
+	defer ctx.Close()
+
+	var client, server recorder
+	conn := serve(ctx, &client, &server, Options{
+		Frames: true,
+		Sample: SampleRates(map[string]float64{"sampled": 1}, 0),
+	})
+	defer func() { _ = conn.Close() }()
+
+	in, out := "hello", ""
+	assert.NoError(t, conn.Invoke(ctx, "unsampled", stringEncoding{}, &in, &out))
+	assert.NoError(t, conn.Invoke(ctx, "sampled", stringEncoding{}, &in, &out))
+	assert.NoError(t, conn.Close())
+	ctx.Close()
+
+	// only the events of the sampled rpc are emitted, and the frames of the
+	// invoke are held until its rpc is known.
+	assert.DeepEqual(t, client.get("write", 6), []string{
+		"write sampled frame s:2 m:1 Invoke",
+		"write sampled packet s:2 m:1 Invoke",
+		"write sampled frame s:2 m:2 Message hello",
+		"write sampled packet s:2 m:2 Message hello",
+		"write sampled frame s:2 m:3 CloseSend",
+		"write sampled packet s:2 m:3 CloseSend",
+	})
+	assert.DeepEqual(t, client.get("read", 2), []string{
+		"read sampled frame s:2 m:1 Message hello",
+		"read sampled packet s:2 m:1 Message hello",
+	})
+}
+
+func TestTransportError(t *testing.T) {
+	var rec recorder
+	c1, c2 := net.Pipe()
+	defer func() { _ = c2.Close() }()
+
+	tr := New(c1, &rec)
+	go func() { _, _ = c2.Write([]byte{0x04, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}) }()
+	_, err := tr.Read(make([]byte, 64))
+	assert.NoError(t, err)
+	assert.DeepEqual(t, rec.get("read", 1), []string{"read error"})
+}
+
+func TestTransportWrite(t *testing.T) {
+	frame := func(stream, message uint64, kind drpcwire.Kind, data string) []byte {
+		return drpcwire.AppendFrame(nil, drpcwire.Frame{
+			ID:   drpcwire.ID{Stream: stream, Message: message},
+			Kind: kind,
+			Data: []byte(data),
+			Done: true,
+		})
+	}
+
+	var rec recorder
+	wt := new(writeTransport)
+	var written []int
+	tr := New(wt, SinkFunc(func(ev Event) {
+		written = append(written, wt.n)
+		rec.Emit(ev)
+	}))
+
+	// the events for the bytes are emitted before the bytes are written.
+	invoke := frame(1, 1, drpcwire.KindInvoke, "rpc")
+	_, err := tr.Write(invoke)
+	assert.NoError(t, err)
+	_, err = tr.Write(frame(1, 2, drpcwire.KindMessage, "hello"))
+	assert.NoError(t, err)
+	assert.DeepEqual(t, written, []int{0, len(invoke)})
+
+	// without a sampling function, the events of a stream whose rpc is not
+	// known yet are not held.
+	_, err = tr.Write(frame(2, 1, drpcwire.KindMessage, "early"))
+	assert.NoError(t, err)
+	assert.DeepEqual(t, rec.get("write", 3), []string{
+		"write rpc packet s:1 m:1 Invoke",
+		"write rpc packet s:1 m:2 Message hello",
+		"write  packet s:2 m:1 Message early",
+	})
+
+	// events are emitted even for bytes that the wrapped transport fails to
+	// write.
+	wt.err = errs.New("write failed")
+	_, err = tr.Write(frame(3, 1, drpcwire.KindMessage, "failed"))
+	assert.Error(t, err)
+	assert.DeepEqual(t, rec.get("write", 4)[3:], []string{
+		"write  packet s:3 m:1 Message failed",
+	})
+}
+
+func TestParser(t *testing.T) {
+	buf := drpcwire.AppendFrame(nil, drpcwire.Frame{
+		ID:   drpcwire.ID{Stream: 1, Message: 1},
+		Kind: drpcwire.KindInvoke,
+		Data: []byte("rpc"),
+		Done: true,
+	})
+	buf = drpcwire.AppendFrame(buf, drpcwire.Frame{
+		ID:   drpcwire.ID{Stream: 1, Message: 2},
+		Kind: drpcwire.KindMessage,
+		Data: []byte("hello"),
+		Done: true,
+	})
+
+	// the bytes may be fed in any pieces.
+	var rec recorder
+	p := NewParser(Read, &rec)
+	for i := range buf {
+		p.Feed(buf[i : i+1])
+	}
+	assert.DeepEqual(t, rec.get("read", 3), []string{
+		"read rpc packet s:1 m:1 Invoke",
+		"read rpc packet s:1 m:2 Message hello",
+	})
+
+	// invalid bytes emit an error.
+	p = NewParser(Write, &rec)
+	p.Feed([]byte{0x04, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.DeepEqual(t, rec.get("write", 1), []string{"write error"})
+}
+
+// recorder is a Sink that records a summary of every event.
+type recorder struct {
+	mu  sync.Mutex
+	evs []string
+}
+
+func (r *recorder) Emit(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var desc string
+	switch {
+	case ev.Err != nil:
+		r.evs = append(r.evs, ev.Direction.String()+" error")
+		return
+	case ev.Frame != nil:
+		desc = "frame " + describe(ev.Frame.ID, ev.Frame.Kind, ev.Frame.Data)
+	case ev.Packet != nil:
+		desc = "packet " + describe(ev.Packet.ID, ev.Packet.Kind, ev.Packet.Data)
+	}
+	r.evs = append(r.evs, fmt.Sprintf("%s %s %s", ev.Direction, ev.RPC, desc))
+}
+
+// get returns up to the first n events in the direction. The order is only
+// guaranteed within a direction.
+func (r *recorder) get(dir string, n int) (out []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ev := range r.evs {
+		if strings.HasPrefix(ev, dir+" ") && len(out) < n {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func describe(id drpcwire.ID, kind drpcwire.Kind, data []byte) string {
+	desc := fmt.Sprintf("s:%d m:%d %s", id.Stream, id.Message, kind)
+	if kind == drpcwire.KindMessage {
+		desc += " " + string(data)
+	}
+	return desc
+}
+
+func serve(ctx *drpctest.Tracker, client, server Sink, opts Options) *drpcconn.Conn {
+	c1, c2 := net.Pipe()
+	lis := NewListener(&pipeListener{conns: []net.Conn{c1}}, server, opts)
+
+	srv := drpcserver.New(echoHandler{})
+	ctx.Run(func(ctx context.Context) {
+		conn, _ := lis.Accept()
+		_ = srv.ServeOne(ctx, conn)
+	})
+	return drpcconn.New(NewWithOptions(c2, client, opts))
+}
+
+type pipeListener struct {
+	net.Listener
+	conns []net.Conn
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	conn := l.conns[0]
+	l.conns = l.conns[1:]
+	return conn, nil
+}
+
+type echoHandler struct{}
+
+func (echoHandler) HandleRPC(stream drpc.Stream, rpc string) error {
+	var msg string
+	if err := stream.MsgRecv(&msg, stringEncoding{}); err != nil {
+		return err
+	}
+	return stream.MsgSend(&msg, stringEncoding{})
+}
+
+// writeTransport is a transport that counts the bytes written to it, or fails
+// the writes with err if it is set.
+type writeTransport struct {
+	drpc.Transport
+	n   int
+	err error
+}
+
+func (w *writeTransport) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.n += len(p)
+	return len(p), nil
+}
+
+type stringEncoding struct{}
+
+func (stringEncoding) Marshal(msg drpc.Message) ([]byte, error) {
+	return []byte(*msg.(*string)), nil
+}
+
+func (stringEncoding) Unmarshal(buf []byte, msg drpc.Message) error {
+	*msg.(*string) = string(buf)
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package drpcharness starts drpc servers for tests in one call.
+//
+// It is separate from drpctest so that the tests of the packages it uses can
+// depend on drpctest.
+package drpcharness
+
+import (
+	"context"
+	"testing"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcconn"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/drpcserver"
+	"storj.io/drpc/drpctest"
+)
+
+// Service is an implementation of a service and the description of its rpcs,
+// like what is passed to a generated DRPCRegister function.
+type Service struct {
+	Impl interface{}
+	Desc drpc.Description
+}
+
+// Options controls configuration settings for the server and connection.
+type Options struct {
+	// Listener controls the options of the in-memory listener.
+	Listener drpctest.ListenerOptions
+
+	// Server controls the options of the server.
+	Server drpcserver.Options
+
+	// Conn controls the options of the returned connection.
+	Conn drpcconn.Options
+}
+
+// New registers the services with a drpcserver that is serving on an
+// in-memory listener and returns a connection to it. The connection and the
+// server are closed when the test finishes.
+func New(tb testing.TB, services ...Service) *drpcconn.Conn {
+	return NewWithOptions(tb, Options{}, services...)
+}
+
+// NewWithOptions is like New but uses the provided options.
+func NewWithOptions(tb testing.TB, opts Options, services ...Service) *drpcconn.Conn {
+	tb.Helper()
+
+	mux := drpcmux.New()
+	for _, svc := range services {
+		if err := mux.Register(svc.Impl, svc.Desc); err != nil {
+			tb.Fatalf("drpcharness: %v", err)
+		}
+	}
+
+	lis := drpctest.NewListenerWithOptions(opts.Listener)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- drpcserver.NewWithOptions(mux, opts.Server).Serve(ctx, lis) }()
+
+	raw, err := lis.DialContext(ctx)
+	if err != nil {
+		cancel()
+		<-served
+		tb.Fatalf("drpcharness: %v", err)
+	}
+	conn := drpcconn.NewWithOptions(raw, opts.Conn)
+
+	tb.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		if err := <-served; err != nil {
+			tb.Errorf("drpcharness: %v", err)
+		}
+	})
+
+	return conn
+}
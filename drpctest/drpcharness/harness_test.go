@@ -0,0 +1,24 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpcharness
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc/drpchealth"
+)
+
+func TestNew(t *testing.T) {
+	health := drpchealth.NewServer()
+	conn := New(t, Service{Impl: health, Desc: drpchealth.DRPCHealthDescription{}})
+
+	ctx := context.Background()
+	assert.NoError(t, drpchealth.Check(ctx, conn, ""))
+
+	health.Shutdown()
+	assert.Error(t, drpchealth.Check(ctx, conn, ""))
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpctest
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// ListenerOptions controls configuration settings for a Listener.
+type ListenerOptions struct {
+	// BufferSize is the number of bytes that can be written in each direction
+	// of a connection before writes block waiting for the remote to read. If
+	// zero, 32KiB is used.
+	BufferSize int
+}
+
+// Listener is an in-memory net.Listener. Connections to it are made with Dial
+// or DialContext and never touch the network.
+type Listener struct {
+	opts  ListenerOptions
+	conns chan net.Conn
+	once  sync.Once
+	done  chan struct{}
+}
+
+var _ net.Listener = (*Listener)(nil)
+
+// NewListener returns a new in-memory Listener.
+func NewListener() *Listener {
+	return NewListenerWithOptions(ListenerOptions{})
+}
+
+// NewListenerWithOptions returns a new in-memory Listener using the provided
+// options.
+func NewListenerWithOptions(opts ListenerOptions) *Listener {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 32 << 10
+	}
+	return &Listener{
+		opts:  opts,
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+// Accept waits for and returns the next connection dialed to the listener.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close closes the listener. Any blocked Accept or Dial calls return errors.
+// Connections that were already established are not closed.
+func (l *Listener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+// Addr returns the address of the listener.
+func (l *Listener) Addr() net.Addr { return addr{} }
+
+// Dial connects to the listener, blocking until the connection is accepted.
+func (l *Listener) Dial() (net.Conn, error) {
+	return l.DialContext(context.Background())
+}
+
+// DialContext connects to the listener, blocking until the connection is
+// accepted or the context is canceled. The address arguments some dialer
+// signatures require can be ignored with a closure.
+func (l *Listener) DialContext(ctx context.Context) (net.Conn, error) {
+	a := &pipe{size: l.opts.BufferSize, changed: make(chan struct{})}
+	b := &pipe{size: l.opts.BufferSize, changed: make(chan struct{})}
+	client, server := newConn(a, b), newConn(b, a)
+
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// addr is the address of both sides of every in-memory connection.
+type addr struct{}
+
+func (addr) Network() string { return "memory" }
+func (addr) String() string  { return "memory" }
+
+//
+// pipe
+//
+
+// pipe is a buffer of bytes flowing in one direction of a connection.
+type pipe struct {
+	mu      sync.Mutex
+	buf     []byte
+	size    int
+	eof     bool          // the writing side has closed
+	broken  bool          // the reading side has closed
+	changed chan struct{} // closed and replaced every time the pipe changes
+}
+
+// notify wakes up anything waiting on the pipe to change. It must be called
+// with the mutex held.
+func (p *pipe) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+//
+// conn
+//
+
+// conn is one side of an in-memory connection.
+type conn struct {
+	rd, wr *pipe
+
+	rdl, wdl deadline
+
+	once sync.Once
+	done chan struct{}
+}
+
+// newConn returns a connection that reads from rd and writes to wr.
+func newConn(rd, wr *pipe) *conn {
+	c := &conn{rd: rd, wr: wr, done: make(chan struct{})}
+	c.rdl.expired = make(chan struct{})
+	c.wdl.expired = make(chan struct{})
+	return c
+}
+
+// Read reads buffered bytes written by the remote, blocking until there are
+// some, the remote closes, or the read deadline passes.
+func (c *conn) Read(p []byte) (n int, err error) {
+	for {
+		select {
+		case <-c.done:
+			return 0, io.ErrClosedPipe
+		case <-c.rdl.wait():
+			return 0, os.ErrDeadlineExceeded
+		default:
+		}
+
+		c.rd.mu.Lock()
+		if len(c.rd.buf) > 0 {
+			n = copy(p, c.rd.buf)
+			c.rd.buf = c.rd.buf[n:]
+			c.rd.notify()
+			c.rd.mu.Unlock()
+			return n, nil
+		} else if c.rd.eof {
+			c.rd.mu.Unlock()
+			return 0, io.EOF
+		}
+		changed := c.rd.changed
+		c.rd.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-c.done:
+		case <-c.rdl.wait():
+		}
+	}
+}
+
+// Write buffers the bytes for the remote, blocking while the buffer is full
+// until the remote reads, the remote closes, or the write deadline passes.
+func (c *conn) Write(p []byte) (n int, err error) {
+	for n < len(p) {
+		select {
+		case <-c.done:
+			return n, io.ErrClosedPipe
+		case <-c.wdl.wait():
+			return n, os.ErrDeadlineExceeded
+		default:
+		}
+
+		c.wr.mu.Lock()
+		if c.wr.broken {
+			c.wr.mu.Unlock()
+			return n, io.ErrClosedPipe
+		} else if space := c.wr.size - len(c.wr.buf); space > 0 {
+			m := min(space, len(p)-n)
+			c.wr.buf = append(c.wr.buf, p[n:n+m]...)
+			n += m
+			c.wr.notify()
+			c.wr.mu.Unlock()
+			continue
+		}
+		changed := c.wr.changed
+		c.wr.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-c.done:
+		case <-c.wdl.wait():
+		}
+	}
+	return n, nil
+}
+
+// Close closes the connection. The remote reads any bytes already written
+// before reading io.EOF.
+func (c *conn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+
+		c.wr.mu.Lock()
+		c.wr.eof = true
+		c.wr.notify()
+		c.wr.mu.Unlock()
+
+		c.rd.mu.Lock()
+		c.rd.broken = true
+		c.rd.buf = nil
+		c.rd.notify()
+		c.rd.mu.Unlock()
+	})
+	return nil
+}
+
+func (c *conn) LocalAddr() net.Addr  { return addr{} }
+func (c *conn) RemoteAddr() net.Addr { return addr{} }
+
+func (c *conn) SetDeadline(t time.Time) error {
+	c.rdl.set(t)
+	c.wdl.set(t)
+	return nil
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.rdl.set(t)
+	return nil
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.wdl.set(t)
+	return nil
+}
+
+//
+// deadline
+//
+
+// deadline is a channel that is closed when a settable time passes. The
+// channel must be created before use.
+type deadline struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	expired chan struct{}
+}
+
+// set changes when the deadline passes. The zero time means never.
+func (d *deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		<-d.expired // the timer fired, so wait for it to close the channel
+	}
+	d.timer = nil
+
+	select {
+	case <-d.expired:
+		d.expired = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		return
+	} else if dur := time.Until(t); dur <= 0 {
+		close(d.expired)
+	} else {
+		expired := d.expired
+		d.timer = time.AfterFunc(dur, func() { close(expired) })
+	}
+}
+
+// wait returns a channel that is closed when the deadline passes.
+func (d *deadline) wait() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.expired
+}
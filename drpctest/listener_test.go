@@ -0,0 +1,95 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package drpctest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/zeebo/assert"
+)
+
+func TestListener(t *testing.T) {
+	ctx := NewTracker(t)
+	defer ctx.Close()
+
+	lis := NewListenerWithOptions(ListenerOptions{BufferSize: 4})
+	defer func() { _ = lis.Close() }()
+
+	ctx.Run(func(ctx context.Context) {
+		conn, err := lis.Accept()
+		assert.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		buf := make([]byte, 11)
+		_, err = io.ReadFull(conn, buf)
+		assert.NoError(t, err)
+		_, err = conn.Write(buf)
+		assert.NoError(t, err)
+	})
+
+	conn, err := lis.Dial()
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	// writes larger than the buffer block until the remote reads.
+	n, err := conn.Write([]byte("hello world"))
+	assert.NoError(t, err)
+	assert.Equal(t, n, 11)
+
+	// the written bytes are read before the remote close.
+	buf, err := io.ReadAll(conn)
+	assert.NoError(t, err)
+	assert.Equal(t, string(buf), "hello world")
+
+	// writes fail once the remote has closed.
+	_, err = conn.Write([]byte("x"))
+	assert.That(t, errors.Is(err, io.ErrClosedPipe))
+
+	// a closed listener rejects dials.
+	assert.NoError(t, lis.Close())
+	_, err = lis.Dial()
+	assert.That(t, errors.Is(err, net.ErrClosed))
+}
+
+func TestListenerDeadlines(t *testing.T) {
+	ctx := NewTracker(t)
+	defer ctx.Close()
+
+	lis := NewListenerWithOptions(ListenerOptions{BufferSize: 1})
+	defer func() { _ = lis.Close() }()
+
+	ctx.Run(func(ctx context.Context) {
+		conn, err := lis.Accept()
+		assert.NoError(t, err)
+		<-ctx.Done()
+		_ = conn.Close()
+	})
+
+	conn, err := lis.Dial()
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.That(t, errors.Is(err, os.ErrDeadlineExceeded))
+
+	// writes return how much fit in the buffer before the deadline.
+	assert.NoError(t, conn.SetWriteDeadline(time.Now().Add(10*time.Millisecond)))
+	n, err := conn.Write([]byte("ab"))
+	assert.That(t, errors.Is(err, os.ErrDeadlineExceeded))
+	assert.Equal(t, n, 1)
+
+	// clearing the deadline allows blocked operations again.
+	assert.NoError(t, conn.SetDeadline(time.Time{}))
+	done := make(chan error, 1)
+	go func() { _, err := conn.Read(make([]byte, 1)); done <- err }()
+	_ = conn.Close()
+	assert.That(t, errors.Is(<-done, io.ErrClosedPipe))
+}
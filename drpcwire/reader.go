@@ -4,7 +4,10 @@
 package drpcwire
 
 import (
+	"errors"
 	"io"
+	"os"
+	"time"
 
 	"storj.io/drpc"
 )
@@ -14,16 +17,41 @@ type ReaderOptions struct {
 	// MaximumBufferSize controls the maximum size of buffered
 	// packet data.
 	MaximumBufferSize int
+
+	// MaximumFramesPerPacket controls the maximum number of frames
+	// that may be read to construct a single packet, including any
+	// frames for packets that were discarded. Zero means unlimited.
+	MaximumFramesPerPacket int
+
+	// FrameTimeout controls the maximum amount of time allowed between
+	// the first byte of a frame being read and the frame being complete.
+	// It is only enforced if OwnsReadDeadline is set and the io.Reader has
+	// a SetReadDeadline method, like a net.Conn. Zero means unlimited.
+	FrameTimeout time.Duration
+
+	// OwnsReadDeadline declares that nothing else sets read deadlines on the
+	// io.Reader, so that the Reader may set one while a frame is partially
+	// read and clear it once the frame is complete to enforce FrameTimeout.
+	// The existing deadline of a net.Conn cannot be retrieved to restore it,
+	// so this must not be set if anything else, like the owner of the
+	// connection, uses read deadlines.
+	OwnsReadDeadline bool
 }
 
 // Reader reconstructs packets from frames read from an io.Reader.
 type Reader struct {
-	opts ReaderOptions
-	r    io.Reader
-	curr []byte
-	buf  []byte
-	id   ID
-	rerr error
+	opts     ReaderOptions
+	r        io.Reader
+	curr     []byte
+	buf      []byte
+	id       ID
+	rerr     error
+	deadline bool
+}
+
+// deadliner is implemented by readers that support read deadlines.
+type deadliner interface {
+	SetReadDeadline(t time.Time) error
 }
 
 // A frame adds at most this many bytes of overhead to some data by prefixing
@@ -73,6 +101,32 @@ func (r *Reader) read(p []byte) (n int, err error) {
 	return 0, drpc.InternalError.Wrap(io.ErrNoProgress)
 }
 
+// setFrameDeadline sets a read deadline on the underlying reader if one is
+// configured, there is a partial frame buffered, and one has not already been
+// set for the frame.
+func (r *Reader) setFrameDeadline() error {
+	if r.opts.FrameTimeout <= 0 || !r.opts.OwnsReadDeadline || r.deadline || len(r.buf) == 0 {
+		return nil
+	}
+	dl, ok := r.r.(deadliner)
+	if !ok {
+		return nil
+	}
+	r.deadline = true
+	return dl.SetReadDeadline(time.Now().Add(r.opts.FrameTimeout))
+}
+
+// clearFrameDeadline removes any read deadline set by setFrameDeadline. It
+// clears the deadline entirely, which is only done if the Reader owns the read
+// deadlines.
+func (r *Reader) clearFrameDeadline() error {
+	if !r.deadline {
+		return nil
+	}
+	r.deadline = false
+	return r.r.(deadliner).SetReadDeadline(time.Time{})
+}
+
 // ReadPacket reads a packet from the io.Reader. It is equivalent to
 // calling ReadPacketUsing(nil).
 func (r *Reader) ReadPacket() (pkt Packet, err error) {
@@ -90,6 +144,7 @@ func (r *Reader) ReadPacketUsing(buf []byte) (pkt Packet, err error) {
 
 	var fr Frame
 	var ok bool
+	var frames int
 
 	for {
 		r.curr, fr, ok, err = ParseFrame(r.curr)
@@ -110,8 +165,15 @@ func (r *Reader) ReadPacketUsing(buf []byte) (pkt Packet, err error) {
 				r.buf = nbuf
 			}
 
+			if err := r.setFrameDeadline(); err != nil {
+				return Packet{}, err
+			}
+
 			n, err := r.read(r.buf[len(r.buf):cap(r.buf)])
 			if err != nil {
+				if r.deadline && errors.Is(err, os.ErrDeadlineExceeded) {
+					return Packet{}, drpc.ProtocolError.New("frame not completed within %v", r.opts.FrameTimeout)
+				}
 				return Packet{}, err
 			}
 
@@ -134,6 +196,9 @@ func (r *Reader) ReadPacketUsing(buf []byte) (pkt Packet, err error) {
 		if len(r.buf) > 0 {
 			r.buf = r.buf[:0]
 		}
+		if err := r.clearFrameDeadline(); err != nil {
+			return Packet{}, err
+		}
 
 		// If any frames are set to control, then the whole packet is
 		// considered to be control.
@@ -158,11 +223,15 @@ func (r *Reader) ReadPacketUsing(buf []byte) (pkt Packet, err error) {
 		}
 
 		pkt.Data = append(pkt.Data, fr.Data...)
+		frames++
 
 		switch {
 		case len(pkt.Data) > r.opts.MaximumBufferSize:
 			return Packet{}, drpc.ProtocolError.New("data overflow (len:%v)", len(pkt.Data))
 
+		case r.opts.MaximumFramesPerPacket > 0 && frames > r.opts.MaximumFramesPerPacket:
+			return Packet{}, drpc.ProtocolError.New("too many frames in packet (frames:%v)", frames)
+
 		case fr.Done:
 			// increment the message id so that we do not accept any frames
 			// with the same id.
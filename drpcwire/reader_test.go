@@ -8,6 +8,7 @@ import (
 	"errors"
 	"io"
 	"math/rand"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -154,6 +155,38 @@ func TestReader(t *testing.T) {
 			Frames: []Frame{{ID: ID{Stream: 0, Message: 1}}},
 			Error:  "id monotonicity violation",
 		},
+
+		{ // packet at the frame limit
+			Packets: []Packet{p(KindMessage, 1, false, "abc")},
+			Frames: []Frame{
+				f(KindMessage, 1, "a", false, false),
+				f(KindMessage, 1, "b", false, false),
+				f(KindMessage, 1, "c", true, false),
+			},
+			Options: ReaderOptions{MaximumFramesPerPacket: 3},
+		},
+
+		{ // too many frames in a packet
+			Frames: []Frame{
+				f(KindMessage, 1, "", false, false),
+				f(KindMessage, 1, "", false, false),
+				f(KindMessage, 1, "", false, false),
+				f(KindMessage, 1, "", true, false),
+			},
+			Error:   "too many frames",
+			Options: ReaderOptions{MaximumFramesPerPacket: 3},
+		},
+
+		{ // too many frames from discarded packets
+			Frames: []Frame{
+				f(KindMessage, 1, "", false, false),
+				f(KindMessage, 2, "", false, false),
+				f(KindMessage, 3, "", false, false),
+				f(KindMessage, 4, "", true, false),
+			},
+			Error:   "too many frames",
+			Options: ReaderOptions{MaximumFramesPerPacket: 3},
+		},
 	}
 
 	for _, tc := range cases {
@@ -179,6 +212,74 @@ func TestReader(t *testing.T) {
 	}
 }
 
+func TestReaderFrameTimeout(t *testing.T) {
+	pr, pw := net.Pipe()
+	defer func() { _ = pr.Close() }()
+	defer func() { _ = pw.Close() }()
+
+	buf := AppendFrame(nil, Frame{
+		Data: []byte("hello"),
+		ID:   ID{Stream: 1, Message: 1},
+		Kind: KindMessage,
+		Done: true,
+	})
+
+	go func() {
+		// send a full frame followed by a partial one that never completes.
+		_, _ = pw.Write(buf)
+		_, _ = pw.Write(buf[:2])
+	}()
+
+	rd := NewReaderWithOptions(pr, ReaderOptions{
+		FrameTimeout:     10 * time.Millisecond,
+		OwnsReadDeadline: true,
+	})
+
+	pkt, err := rd.ReadPacket()
+	assert.NoError(t, err)
+	assert.Equal(t, string(pkt.Data), "hello")
+
+	_, err = rd.ReadPacket()
+	assert.Error(t, err)
+	assert.That(t, strings.Contains(err.Error(), "frame not completed"))
+}
+
+func TestReaderFrameTimeoutNotOwned(t *testing.T) {
+	dl := new(deadlineRecorder)
+	dl.r = bytes.NewReader(AppendFrame(nil, Frame{
+		Data: []byte("hello"),
+		ID:   ID{Stream: 1, Message: 1},
+		Kind: KindMessage,
+		Done: true,
+	}))
+
+	// without owning the read deadline, the reader never touches it.
+	rd := NewReaderWithOptions(dl, ReaderOptions{FrameTimeout: time.Second})
+	pkt, err := rd.ReadPacket()
+	assert.NoError(t, err)
+	assert.Equal(t, string(pkt.Data), "hello")
+	assert.Equal(t, dl.calls, 0)
+}
+
+// deadlineRecorder is an io.Reader that counts calls to SetReadDeadline.
+type deadlineRecorder struct {
+	r     io.Reader
+	calls int
+}
+
+func (d *deadlineRecorder) Read(p []byte) (int, error) {
+	// read a byte at a time so that frames are partially read.
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	return d.r.Read(p)
+}
+
+func (d *deadlineRecorder) SetReadDeadline(time.Time) error {
+	d.calls++
+	return nil
+}
+
 func TestReaderRandomized(t *testing.T) {
 	seed := time.Now().UnixNano()
 	t.Log("seed:", seed)
@@ -21,27 +21,57 @@ type Writer struct {
 	empty uint32
 	w     io.Writer
 	size  int
+	opts  WriterOptions
 	mu    sync.Mutex
 	buf   []byte
 }
 
+// WriterOptions controls configuration settings for a writer.
+type WriterOptions struct {
+	// Tracer receives debug events about flushes. If nil, drpcdebug.Global
+	// is used.
+	Tracer *drpcdebug.Tracer
+
+	// RPC returns the rpc of the stream that is currently writing, so that
+	// the flush events are traced for it. If nil, they are traced as not
+	// being about a specific rpc.
+	RPC func() string
+}
+
 // NewWriter returns a Writer that will attempt to buffer size data before
 // sending it to the io.Writer.
 func NewWriter(w io.Writer, size int) *Writer {
+	return NewWriterWithOptions(w, size, WriterOptions{})
+}
+
+// NewWriterWithOptions returns a Writer that will attempt to buffer size data
+// before sending it to the io.Writer using the provided options.
+func NewWriterWithOptions(w io.Writer, size int, opts WriterOptions) *Writer {
 	if size == 0 {
 		size = 4 * 1024
 	}
+	if opts.Tracer == nil {
+		opts.Tracer = drpcdebug.Global
+	}
 
 	return &Writer{
 		w:    w,
 		size: size,
+		opts: opts,
 		buf:  make([]byte, 0, size),
 	}
 }
 
 func (b *Writer) log(what string, cb func() string) {
-	if drpcdebug.Enabled {
-		drpcdebug.Log(func() (_, _, _ string) { return fmt.Sprintf("<wri %p>", b), what, cb() })
+	if !b.opts.Tracer.Active() {
+		return
+	}
+	var rpc string
+	if b.opts.RPC != nil {
+		rpc = b.opts.RPC()
+	}
+	if b.opts.Tracer.Enabled(rpc) {
+		b.opts.Tracer.Log(rpc, func() (_, _, _ string) { return fmt.Sprintf("<wri %p>", b), what, cb() })
 	}
 }
 
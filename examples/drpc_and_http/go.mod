@@ -1,6 +1,6 @@
 module storj.io/drpc/examples/drpc_and_http
 
-go 1.19
+go 1.21
 
 require (
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
@@ -90,6 +90,22 @@ func (DRPCCookieMonsterDescription) Method(n int) (string, drpc.Encoding, drpc.R
 	}
 }
 
+func (DRPCCookieMonsterDescription) MethodInfo(n int) (drpc.MethodInfo, bool) {
+	switch n {
+	case 0:
+		return drpc.MethodInfo{
+			Service:    "sesamestreet.CookieMonster",
+			Method:     "EatCookie",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(Cookie) },
+			NewOut:     func() drpc.Message { return new(Crumbs) },
+			Descriptor: File_sesamestreet_proto.Services().Get(0).Methods().Get(0),
+		}, true
+	default:
+		return drpc.MethodInfo{}, false
+	}
+}
+
 func DRPCRegisterCookieMonster(mux drpc.Mux, impl DRPCCookieMonsterServer) error {
 	return mux.Register(impl, DRPCCookieMonsterDescription{})
 }
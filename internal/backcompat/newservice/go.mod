@@ -1,6 +1,6 @@
 module storj.io/drpc/internal/backcompat/newservice
 
-go 1.19
+go 1.21
 
 require storj.io/drpc/internal/backcompat v0.0.0-00010101000000-000000000000
 
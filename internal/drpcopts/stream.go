@@ -5,6 +5,7 @@ package drpcopts
 
 import (
 	"storj.io/drpc"
+	"storj.io/drpc/drpcdebug"
 	"storj.io/drpc/drpcstats"
 )
 
@@ -15,6 +16,7 @@ type Stream struct {
 	kind      string
 	rpc       string
 	stats     *drpcstats.Stats
+	tracer    *drpcdebug.Tracer
 }
 
 // GetStreamTransport returns the drpc.Transport stored in the options.
@@ -46,3 +48,9 @@ func GetStreamStats(opts *Stream) *drpcstats.Stats { return opts.stats }
 
 // SetStreamStats sets the Stats stored in the options.
 func SetStreamStats(opts *Stream, stats *drpcstats.Stats) { opts.stats = stats }
+
+// GetStreamTracer returns the Tracer stored in the options.
+func GetStreamTracer(opts *Stream) *drpcdebug.Tracer { return opts.tracer }
+
+// SetStreamTracer sets the Tracer stored in the options.
+func SetStreamTracer(opts *Stream, tracer *drpcdebug.Tracer) { opts.tracer = tracer }
@@ -1,6 +1,6 @@
 module storj.io/drpc/internal/grpccompat
 
-go 1.19
+go 1.21
 
 require (
 	github.com/improbable-eng/grpc-web v0.15.0
@@ -257,6 +257,49 @@ func (DRPCServiceDescription) Method(n int) (string, drpc.Encoding, drpc.Receive
 	}
 }
 
+func (DRPCServiceDescription) MethodInfo(n int) (drpc.MethodInfo, bool) {
+	switch n {
+	case 0:
+		return drpc.MethodInfo{
+			Service:    "service.Service",
+			Method:     "Method1",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(In) },
+			NewOut:     func() drpc.Message { return new(Out) },
+			Descriptor: File_service_proto.Services().Get(0).Methods().Get(0),
+		}, true
+	case 1:
+		return drpc.MethodInfo{
+			Service:    "service.Service",
+			Method:     "Method2",
+			Kind:       drpc.MethodClientStream,
+			NewIn:      func() drpc.Message { return new(In) },
+			NewOut:     func() drpc.Message { return new(Out) },
+			Descriptor: File_service_proto.Services().Get(0).Methods().Get(1),
+		}, true
+	case 2:
+		return drpc.MethodInfo{
+			Service:    "service.Service",
+			Method:     "Method3",
+			Kind:       drpc.MethodServerStream,
+			NewIn:      func() drpc.Message { return new(In) },
+			NewOut:     func() drpc.Message { return new(Out) },
+			Descriptor: File_service_proto.Services().Get(0).Methods().Get(2),
+		}, true
+	case 3:
+		return drpc.MethodInfo{
+			Service:    "service.Service",
+			Method:     "Method4",
+			Kind:       drpc.MethodBidiStream,
+			NewIn:      func() drpc.Message { return new(In) },
+			NewOut:     func() drpc.Message { return new(Out) },
+			Descriptor: File_service_proto.Services().Get(0).Methods().Get(3),
+		}, true
+	default:
+		return drpc.MethodInfo{}, false
+	}
+}
+
 func DRPCRegisterService(mux drpc.Mux, impl DRPCServiceServer) error {
 	return mux.Register(impl, DRPCServiceDescription{})
 }
@@ -19,8 +19,9 @@ type (
 	In  = service.In
 	Out = service.Out
 
-	DRPCServiceServer = service.DRPCServiceServer
-	DRPCServiceClient = service.DRPCServiceClient
+	DRPCServiceServer      = service.DRPCServiceServer
+	DRPCServiceClient      = service.DRPCServiceClient
+	DRPCServiceDescription = service.DRPCServiceDescription
 
 	DRPCService_Method2Stream = service.DRPCService_Method2Stream
 	DRPCService_Method3Stream = service.DRPCService_Method3Stream
@@ -6,6 +6,7 @@ package service
 import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	math "math"
 )
 
@@ -112,26 +113,40 @@ func (m *Out) GetData() []byte {
 	return nil
 }
 
+var E_Idempotent = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         50000,
+	Name:          "service.idempotent",
+	Tag:           "varint,50000,opt,name=idempotent",
+	Filename:      "service.proto",
+}
+
 func init() {
 	proto.RegisterType((*In)(nil), "service.In")
 	proto.RegisterType((*Out)(nil), "service.Out")
+	proto.RegisterExtension(E_Idempotent)
 }
 
 func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
 
 var fileDescriptor_a0b84a42fa06f626 = []byte{
-	// 202 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2d, 0x4e, 0x2d, 0x2a,
-	0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x87, 0x72, 0x95, 0x34, 0xb8,
-	0x98, 0x3c, 0xf3, 0x84, 0xf8, 0xb8, 0x98, 0x32, 0xf3, 0x24, 0x18, 0x15, 0x18, 0x35, 0x98, 0x83,
-	0x98, 0x32, 0xf3, 0x84, 0x84, 0xb8, 0x58, 0x52, 0x12, 0x4b, 0x12, 0x25, 0x98, 0x14, 0x18, 0x35,
-	0x78, 0x82, 0xc0, 0x6c, 0x25, 0x6d, 0x2e, 0x66, 0xff, 0xd2, 0x12, 0x21, 0x01, 0x2e, 0xe6, 0xfc,
-	0xd2, 0x12, 0xa8, 0x5a, 0x10, 0x13, 0x9b, 0x62, 0xa3, 0x95, 0x8c, 0x5c, 0xec, 0xc1, 0x10, 0x2b,
-	0x84, 0x54, 0xb8, 0xd8, 0x7d, 0x53, 0x4b, 0x32, 0xf2, 0x53, 0x0c, 0x85, 0xb8, 0xf5, 0x60, 0xce,
-	0xf0, 0xcc, 0x93, 0xe2, 0x81, 0x73, 0x40, 0xe6, 0xaa, 0xc1, 0x54, 0x19, 0xe1, 0x51, 0xa5, 0xc1,
-	0x88, 0x50, 0x67, 0x8c, 0x47, 0x9d, 0x01, 0xa3, 0x90, 0x06, 0x4c, 0x9d, 0x09, 0x5e, 0xf3, 0x0c,
-	0x18, 0x9d, 0x74, 0xa2, 0xb4, 0x8a, 0x4b, 0xf2, 0x8b, 0xb2, 0xf4, 0x32, 0xf3, 0xf5, 0x53, 0x8a,
-	0x0a, 0x92, 0xf5, 0x33, 0xf3, 0x4a, 0x52, 0x8b, 0xf2, 0x12, 0x73, 0xc0, 0x8c, 0xf4, 0xa2, 0xc4,
-	0x92, 0xcc, 0xfc, 0x3c, 0x7d, 0xa8, 0xae, 0x24, 0x36, 0x70, 0x00, 0x1a, 0x03, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x7a, 0xab, 0xb7, 0x51, 0x51, 0x01, 0x00, 0x00,
+	// 269 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x90, 0x3f, 0x4f, 0xc3, 0x30,
+	0x14, 0xc4, 0xe5, 0xa4, 0x22, 0xc8, 0x14, 0x84, 0xde, 0x14, 0x75, 0x40, 0x51, 0x06, 0x64, 0x15,
+	0xe4, 0x94, 0x96, 0x89, 0x09, 0xb1, 0x75, 0x40, 0x91, 0xc2, 0xc6, 0x96, 0x26, 0x26, 0x18, 0x15,
+	0x3f, 0xcb, 0x79, 0x61, 0x66, 0xe2, 0xeb, 0x30, 0xf1, 0x3d, 0xf8, 0x48, 0xa8, 0xf9, 0x83, 0x3a,
+	0x54, 0xd9, 0xee, 0x59, 0xbf, 0x3b, 0x9f, 0x8e, 0x9f, 0xd6, 0xca, 0x7d, 0xe8, 0x42, 0x49, 0xeb,
+	0x90, 0x10, 0x82, 0xfe, 0x9c, 0x45, 0x15, 0x62, 0xb5, 0x55, 0x49, 0xfb, 0xbc, 0x69, 0x5e, 0x92,
+	0x52, 0xd5, 0x85, 0xd3, 0x96, 0xd0, 0x75, 0x68, 0x2c, 0xb8, 0xb7, 0x36, 0x70, 0xc6, 0x3d, 0x6d,
+	0x42, 0x16, 0x31, 0xe1, 0x67, 0x9e, 0x36, 0x00, 0x7c, 0x52, 0xe6, 0x94, 0x87, 0x5e, 0xc4, 0xc4,
+	0x34, 0x6b, 0x75, 0x7c, 0xc5, 0xfd, 0xb4, 0x21, 0x38, 0xe7, 0x3e, 0x36, 0xd4, 0xb3, 0x3b, 0x79,
+	0x08, 0x5e, 0x7e, 0x33, 0x1e, 0x3c, 0x75, 0x25, 0x60, 0xce, 0x83, 0x47, 0x45, 0xaf, 0x58, 0xde,
+	0xc0, 0x89, 0x1c, 0x8a, 0xae, 0xcd, 0x6c, 0xfa, 0x7f, 0xa4, 0x0d, 0xc5, 0x93, 0xcf, 0x9f, 0x90,
+	0xc1, 0xe5, 0xc0, 0x2e, 0x47, 0x58, 0xb1, 0xc7, 0xad, 0x46, 0xb8, 0x05, 0x03, 0x31, 0x70, 0xb7,
+	0xa3, 0x79, 0x0b, 0x76, 0x77, 0xcf, 0xb9, 0x2e, 0xd5, 0xbb, 0x45, 0x52, 0x86, 0xe0, 0x42, 0x76,
+	0xcb, 0xc9, 0x61, 0x39, 0xd9, 0xc5, 0xa4, 0x96, 0x34, 0x9a, 0x3a, 0xfc, 0xfd, 0xf2, 0x23, 0x26,
+	0x8e, 0xb3, 0x3d, 0xcf, 0xc3, 0xf5, 0xf3, 0xbc, 0x26, 0x74, 0x6f, 0x52, 0x63, 0x52, 0x3a, 0x5b,
+	0x24, 0xda, 0x90, 0x72, 0x26, 0xdf, 0xb6, 0xa2, 0x72, 0xf9, 0xce, 0x9a, 0xf4, 0xff, 0x6e, 0x8e,
+	0xda, 0xe4, 0xd5, 0xdf, 0x00, 0xdf, 0x52, 0x93, 0x6d, 0xbb, 0x01, 0x00, 0x00,
 }
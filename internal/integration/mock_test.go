@@ -0,0 +1,78 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build !gogo && !custom
+// +build !gogo,!custom
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/zeebo/assert"
+
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/internal/integration/service"
+)
+
+func TestMockClient(t *testing.T) {
+	ctx := context.Background()
+	stream := &service.DRPCService_Method4MockClient{
+		Responses: []*service.Out{{Out: 1}, {Out: 2}},
+		Err:       drpcerr.WithCode(errors.New("boom"), 5),
+	}
+	mock := &service.DRPCServiceMockClient{
+		Method1Func: func(ctx context.Context, in *service.In) (*service.Out, error) {
+			return &service.Out{Out: in.In + 1}, nil
+		},
+		Method4Func: func(ctx context.Context) (service.DRPCService_Method4Client, error) {
+			return stream, nil
+		},
+	}
+	var client service.DRPCServiceClient = mock
+
+	// unary methods return the programmed response.
+	out, err := client.Method1(ctx, &service.In{In: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, out.Out, 2)
+
+	// methods that are not programmed are unimplemented.
+	_, err = client.Method3(ctx, &service.In{In: 3})
+	assert.Equal(t, drpcerr.Code(err), drpcerr.Unimplemented)
+
+	// streams return the scripted responses and then the error.
+	st, err := client.Method4(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, st.Send(&service.In{In: 4}))
+	assert.NoError(t, st.CloseSend())
+	assert.Error(t, st.Send(&service.In{In: 5}))
+
+	var got service.Out
+	assert.NoError(t, st.MsgRecv(&got, service.Encoding))
+	assert.Equal(t, got.Out, 1)
+	resp, err := st.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Out, 2)
+	_, err = st.Recv()
+	assert.Equal(t, drpcerr.Code(err), 5)
+
+	assert.Equal(t, len(stream.Sent()), 1)
+	assert.Equal(t, stream.Sent()[0].In, 4)
+	assert.That(t, stream.SendClosed())
+
+	// the calls are recorded in order.
+	calls := mock.Calls()
+	assert.Equal(t, len(calls), 3)
+	assert.Equal(t, calls[0].RPC, "/service.Service/Method1")
+	assert.Equal(t, calls[0].In.(*service.In).In, 1)
+	assert.Equal(t, calls[1].RPC, "/service.Service/Method3")
+	assert.Equal(t, calls[2].RPC, "/service.Service/Method4")
+	assert.Nil(t, calls[2].In)
+
+	// streams without an error end with io.EOF.
+	_, err = (&service.DRPCService_Method3MockClient{}).Recv()
+	assert.That(t, errors.Is(err, io.EOF))
+}
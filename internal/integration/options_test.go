@@ -0,0 +1,72 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build !gogo && !custom
+// +build !gogo,!custom
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zeebo/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"storj.io/drpc"
+	"storj.io/drpc/drpcinproc"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/internal/integration/service"
+)
+
+func TestMethodOptions(t *testing.T) {
+	mux := drpcmux.New()
+	assert.NoError(t, DRPCRegisterService(mux, standardImpl))
+
+	idempotent := func(rpc string) bool {
+		data, ok := mux.RPC(rpc)
+		assert.That(t, ok)
+
+		var opts descriptorpb.MethodOptions
+		assert.NoError(t, proto.Unmarshal(data.Options, &opts))
+		return proto.GetExtension(&opts, service.E_Idempotent).(bool)
+	}
+
+	assert.That(t, idempotent("/service.Service/Method1"))
+	assert.That(t, !idempotent("/service.Service/Method2"))
+
+	_, ok := mux.RPC("/service.Service/Unknown")
+	assert.That(t, !ok)
+
+	// handlers wrapping the mux can act on the options of the rpcs.
+	var seen []bool
+	conn := drpcinproc.New(handlerFunc(func(stream drpc.Stream, rpc string) error {
+		seen = append(seen, idempotent(rpc))
+		return mux.HandleRPC(stream, rpc)
+	}))
+	defer func() { _ = conn.Close() }()
+
+	client := NewDRPCServiceClient(conn)
+	_, err := client.Method1(context.Background(), in(1))
+	assert.NoError(t, err)
+	assert.DeepEqual(t, seen, []bool{true})
+}
+
+func TestMethodDescriptor(t *testing.T) {
+	mux := drpcmux.New()
+	assert.NoError(t, DRPCRegisterService(mux, standardImpl))
+
+	rpc, ok := mux.RPC("/service.Service/Method3")
+	assert.That(t, ok)
+
+	md, ok := rpc.Descriptor.(protoreflect.MethodDescriptor)
+	assert.That(t, ok)
+	assert.Equal(t, md.FullName(), protoreflect.FullName("service.Service.Method3"))
+	assert.That(t, md.IsStreamingServer() && !md.IsStreamingClient())
+}
+
+type handlerFunc func(stream drpc.Stream, rpc string) error
+
+func (fn handlerFunc) HandleRPC(stream drpc.Stream, rpc string) error { return fn(stream, rpc) }
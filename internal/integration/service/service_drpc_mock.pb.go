@@ -0,0 +1,400 @@
+// Code generated by protoc-gen-go-drpc. DO NOT EDIT.
+// protoc-gen-go-drpc version: (devel)
+// source: service.proto
+
+package service
+
+import (
+	context "context"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	drpc "storj.io/drpc"
+	drpcerr "storj.io/drpc/drpcerr"
+	sync "sync"
+)
+
+type DRPCServiceMockCall struct {
+	RPC string
+	In  drpc.Message
+}
+
+type DRPCServiceMockClient struct {
+	Conn drpc.Conn
+
+	Method1Func func(ctx context.Context, in *In) (*Out, error)
+	Method2Func func(ctx context.Context) (DRPCService_Method2Client, error)
+	Method3Func func(ctx context.Context, in *In) (DRPCService_Method3Client, error)
+	Method4Func func(ctx context.Context) (DRPCService_Method4Client, error)
+
+	mu    sync.Mutex
+	calls []DRPCServiceMockCall
+}
+
+var _ DRPCServiceClient = (*DRPCServiceMockClient)(nil)
+
+func (c *DRPCServiceMockClient) DRPCConn() drpc.Conn { return c.Conn }
+
+func (c *DRPCServiceMockClient) Calls() []DRPCServiceMockCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]DRPCServiceMockCall(nil), c.calls...)
+}
+
+func (c *DRPCServiceMockClient) record(rpc string, in drpc.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, DRPCServiceMockCall{RPC: rpc, In: in})
+}
+
+func (c *DRPCServiceMockClient) Method1(ctx context.Context, in *In) (*Out, error) {
+	c.record("/service.Service/Method1", in)
+	if c.Method1Func == nil {
+		return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+	}
+	return c.Method1Func(ctx, in)
+}
+
+func (c *DRPCServiceMockClient) Method2(ctx context.Context) (DRPCService_Method2Client, error) {
+	c.record("/service.Service/Method2", nil)
+	if c.Method2Func == nil {
+		return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+	}
+	return c.Method2Func(ctx)
+}
+
+func (c *DRPCServiceMockClient) Method3(ctx context.Context, in *In) (DRPCService_Method3Client, error) {
+	c.record("/service.Service/Method3", in)
+	if c.Method3Func == nil {
+		return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+	}
+	return c.Method3Func(ctx, in)
+}
+
+func (c *DRPCServiceMockClient) Method4(ctx context.Context) (DRPCService_Method4Client, error) {
+	c.record("/service.Service/Method4", nil)
+	if c.Method4Func == nil {
+		return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+	}
+	return c.Method4Func(ctx)
+}
+
+type DRPCService_Method2MockClient struct {
+	Ctx       context.Context
+	Responses []*Out
+	Err       error
+	SendErr   error
+
+	mu         sync.Mutex
+	sent       []*In
+	sendClosed bool
+	closed     bool
+}
+
+var _ DRPCService_Method2Client = (*DRPCService_Method2MockClient)(nil)
+
+func (x *DRPCService_Method2MockClient) Context() context.Context {
+	if x.Ctx == nil {
+		return context.Background()
+	}
+	return x.Ctx
+}
+
+func (x *DRPCService_Method2MockClient) Sent() []*In {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]*In(nil), x.sent...)
+}
+
+func (x *DRPCService_Method2MockClient) SendClosed() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.sendClosed
+}
+
+func (x *DRPCService_Method2MockClient) Closed() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.closed
+}
+
+func (x *DRPCService_Method2MockClient) MsgSend(msg drpc.Message, enc drpc.Encoding) error {
+	m, ok := msg.(*In)
+	if !ok {
+		return fmt.Errorf("mock stream: unexpected message type %T", msg)
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.SendErr != nil {
+		return x.SendErr
+	}
+	if x.sendClosed || x.closed {
+		return io.EOF
+	}
+	x.sent = append(x.sent, m)
+	return nil
+}
+
+func (x *DRPCService_Method2MockClient) MsgRecv(msg drpc.Message, enc drpc.Encoding) error {
+	m, err := x.recv()
+	if err != nil {
+		return err
+	}
+	buf, err := enc.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return enc.Unmarshal(buf, msg)
+}
+
+func (x *DRPCService_Method2MockClient) recv() (*Out, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.closed {
+		return nil, io.EOF
+	}
+	if len(x.Responses) > 0 {
+		m := x.Responses[0]
+		x.Responses = x.Responses[1:]
+		return m, nil
+	}
+	if x.Err != nil {
+		return nil, x.Err
+	}
+	return nil, io.EOF
+}
+
+func (x *DRPCService_Method2MockClient) CloseSend() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.sendClosed = true
+	return nil
+}
+
+func (x *DRPCService_Method2MockClient) Close() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.closed = true
+	return nil
+}
+
+func (x *DRPCService_Method2MockClient) Send(m *In) error {
+	return x.MsgSend(m, nil)
+}
+
+func (x *DRPCService_Method2MockClient) CloseAndRecv() (*Out, error) {
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x.recv()
+}
+
+type DRPCService_Method3MockClient struct {
+	Ctx       context.Context
+	Responses []*Out
+	Err       error
+	SendErr   error
+
+	mu         sync.Mutex
+	sent       []*In
+	sendClosed bool
+	closed     bool
+}
+
+var _ DRPCService_Method3Client = (*DRPCService_Method3MockClient)(nil)
+
+func (x *DRPCService_Method3MockClient) Context() context.Context {
+	if x.Ctx == nil {
+		return context.Background()
+	}
+	return x.Ctx
+}
+
+func (x *DRPCService_Method3MockClient) Sent() []*In {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]*In(nil), x.sent...)
+}
+
+func (x *DRPCService_Method3MockClient) SendClosed() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.sendClosed
+}
+
+func (x *DRPCService_Method3MockClient) Closed() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.closed
+}
+
+func (x *DRPCService_Method3MockClient) MsgSend(msg drpc.Message, enc drpc.Encoding) error {
+	m, ok := msg.(*In)
+	if !ok {
+		return fmt.Errorf("mock stream: unexpected message type %T", msg)
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.SendErr != nil {
+		return x.SendErr
+	}
+	if x.sendClosed || x.closed {
+		return io.EOF
+	}
+	x.sent = append(x.sent, m)
+	return nil
+}
+
+func (x *DRPCService_Method3MockClient) MsgRecv(msg drpc.Message, enc drpc.Encoding) error {
+	m, err := x.recv()
+	if err != nil {
+		return err
+	}
+	buf, err := enc.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return enc.Unmarshal(buf, msg)
+}
+
+func (x *DRPCService_Method3MockClient) recv() (*Out, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.closed {
+		return nil, io.EOF
+	}
+	if len(x.Responses) > 0 {
+		m := x.Responses[0]
+		x.Responses = x.Responses[1:]
+		return m, nil
+	}
+	if x.Err != nil {
+		return nil, x.Err
+	}
+	return nil, io.EOF
+}
+
+func (x *DRPCService_Method3MockClient) CloseSend() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.sendClosed = true
+	return nil
+}
+
+func (x *DRPCService_Method3MockClient) Close() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.closed = true
+	return nil
+}
+
+func (x *DRPCService_Method3MockClient) Recv() (*Out, error) {
+	return x.recv()
+}
+
+type DRPCService_Method4MockClient struct {
+	Ctx       context.Context
+	Responses []*Out
+	Err       error
+	SendErr   error
+
+	mu         sync.Mutex
+	sent       []*In
+	sendClosed bool
+	closed     bool
+}
+
+var _ DRPCService_Method4Client = (*DRPCService_Method4MockClient)(nil)
+
+func (x *DRPCService_Method4MockClient) Context() context.Context {
+	if x.Ctx == nil {
+		return context.Background()
+	}
+	return x.Ctx
+}
+
+func (x *DRPCService_Method4MockClient) Sent() []*In {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]*In(nil), x.sent...)
+}
+
+func (x *DRPCService_Method4MockClient) SendClosed() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.sendClosed
+}
+
+func (x *DRPCService_Method4MockClient) Closed() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.closed
+}
+
+func (x *DRPCService_Method4MockClient) MsgSend(msg drpc.Message, enc drpc.Encoding) error {
+	m, ok := msg.(*In)
+	if !ok {
+		return fmt.Errorf("mock stream: unexpected message type %T", msg)
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.SendErr != nil {
+		return x.SendErr
+	}
+	if x.sendClosed || x.closed {
+		return io.EOF
+	}
+	x.sent = append(x.sent, m)
+	return nil
+}
+
+func (x *DRPCService_Method4MockClient) MsgRecv(msg drpc.Message, enc drpc.Encoding) error {
+	m, err := x.recv()
+	if err != nil {
+		return err
+	}
+	buf, err := enc.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return enc.Unmarshal(buf, msg)
+}
+
+func (x *DRPCService_Method4MockClient) recv() (*Out, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.closed {
+		return nil, io.EOF
+	}
+	if len(x.Responses) > 0 {
+		m := x.Responses[0]
+		x.Responses = x.Responses[1:]
+		return m, nil
+	}
+	if x.Err != nil {
+		return nil, x.Err
+	}
+	return nil, io.EOF
+}
+
+func (x *DRPCService_Method4MockClient) CloseSend() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.sendClosed = true
+	return nil
+}
+
+func (x *DRPCService_Method4MockClient) Close() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.closed = true
+	return nil
+}
+
+func (x *DRPCService_Method4MockClient) Send(m *In) error {
+	return x.MsgSend(m, nil)
+}
+
+func (x *DRPCService_Method4MockClient) Recv() (*Out, error) {
+	return x.recv()
+}
@@ -9,12 +9,15 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"reflect"
 	"testing"
 
 	"github.com/zeebo/assert"
 
+	"storj.io/drpc"
 	"storj.io/drpc/drpcconn"
 	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcinproc"
 	"storj.io/drpc/drpcmux"
 	"storj.io/drpc/drpcserver"
 	"storj.io/drpc/drpcstats"
@@ -113,6 +116,49 @@ func TestConcurrent(t *testing.T) {
 	}
 }
 
+func TestMuxRPCs(t *testing.T) {
+	// the generated description provides the shape of its methods through
+	// drpc.DescriptionInfo, and hiding it makes the mux inspect the method
+	// expressions with reflection instead. both must agree.
+	for _, desc := range []drpc.Description{
+		DRPCServiceDescription{},
+		struct{ drpc.Description }{DRPCServiceDescription{}},
+	} {
+		mux := drpcmux.New()
+		assert.NoError(t, mux.Register(standardImpl, desc))
+
+		kinds := make(map[string]drpc.MethodKind)
+		for _, rpc := range mux.RPCs() {
+			kinds[rpc.Name] = rpc.Kind
+			assert.Equal(t, rpc.In, reflect.TypeOf(new(In)))
+			assert.Equal(t, rpc.Out, reflect.TypeOf(new(Out)))
+		}
+
+		assert.Equal(t, kinds, map[string]drpc.MethodKind{
+			"/service.Service/Method1": drpc.MethodUnary,
+			"/service.Service/Method2": drpc.MethodClientStream,
+			"/service.Service/Method3": drpc.MethodServerStream,
+			"/service.Service/Method4": drpc.MethodBidiStream,
+		})
+
+		conn := drpcinproc.New(mux)
+		cli := NewDRPCServiceClient(conn)
+
+		out, err := cli.Method1(context.Background(), in(1))
+		assert.NoError(t, err)
+		assert.True(t, Equal(out, &Out{Out: 1}))
+
+		stream, err := cli.Method3(context.Background(), in(3))
+		assert.NoError(t, err)
+		out, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.True(t, Equal(out, &Out{Out: 3}))
+
+		assert.NoError(t, stream.Close())
+		assert.NoError(t, conn.Close())
+	}
+}
+
 func TestServerStats(t *testing.T) {
 	ctx := drpctest.NewTracker(t)
 	defer ctx.Close()
@@ -135,15 +181,15 @@ func TestServerStats(t *testing.T) {
 	_, err := cli.Method1(ctx, in(5))
 	assert.Error(t, err)
 
-	assert.Equal(t, srv.Stats(), map[string]drpcstats.Stats{
-		"/service.Service/Method1": {Read: 2, Written: 12},
+	assert.Equal(t, counters(srv.Stats()), map[string]counts{
+		"/service.Service/Method1": {Read: 2, Written: 12, Calls: 1, MessagesRead: 1, Errors: map[uint64]uint64{5: 1}},
 	})
 
 	_, err = cli.Method1(ctx, in(1))
 	assert.NoError(t, err)
 
-	assert.Equal(t, srv.Stats(), map[string]drpcstats.Stats{
-		"/service.Service/Method1": {Read: 2 + 2, Written: 12 + 2},
+	assert.Equal(t, counters(srv.Stats()), map[string]counts{
+		"/service.Service/Method1": {Read: 2 + 2, Written: 12 + 2, Calls: 2, MessagesRead: 2, MessagesWritten: 1, Errors: map[uint64]uint64{5: 1}},
 	})
 
 	stream, err := cli.Method3(ctx, in(3))
@@ -156,9 +202,9 @@ func TestServerStats(t *testing.T) {
 	assert.That(t, errors.Is(err, io.EOF))
 	assert.NoError(t, stream.Close())
 
-	assert.Equal(t, srv.Stats(), map[string]drpcstats.Stats{
-		"/service.Service/Method1": {Read: 2 + 2, Written: 12 + 2},
-		"/service.Service/Method3": {Read: 2, Written: 6},
+	assert.Equal(t, counters(srv.Stats()), map[string]counts{
+		"/service.Service/Method1": {Read: 2 + 2, Written: 12 + 2, Calls: 2, MessagesRead: 2, MessagesWritten: 1, Errors: map[uint64]uint64{5: 1}},
+		"/service.Service/Method3": {Read: 2, Written: 6, Calls: 1, MessagesRead: 1, MessagesWritten: 3},
 	})
 }
 
@@ -184,15 +230,15 @@ func TestClientStats(t *testing.T) {
 	_, err := cli.Method1(ctx, in(5))
 	assert.Error(t, err)
 
-	assert.Equal(t, conn.Stats(), map[string]drpcstats.Stats{
-		"/service.Service/Method1": {Read: 12, Written: 26},
+	assert.Equal(t, counters(conn.Stats()), map[string]counts{
+		"/service.Service/Method1": {Read: 12, Written: 26, Calls: 1, MessagesWritten: 1, Errors: map[uint64]uint64{5: 1}},
 	})
 
 	_, err = cli.Method1(ctx, in(1))
 	assert.NoError(t, err)
 
-	assert.Equal(t, conn.Stats(), map[string]drpcstats.Stats{
-		"/service.Service/Method1": {Read: 12 + 2, Written: 26 + 26},
+	assert.Equal(t, counters(conn.Stats()), map[string]counts{
+		"/service.Service/Method1": {Read: 12 + 2, Written: 26 + 26, Calls: 2, MessagesRead: 1, MessagesWritten: 2, Errors: map[uint64]uint64{5: 1}},
 	})
 
 	stream, err := cli.Method3(ctx, in(3))
@@ -205,8 +251,39 @@ func TestClientStats(t *testing.T) {
 	assert.That(t, errors.Is(err, io.EOF))
 	assert.NoError(t, stream.Close())
 
-	assert.Equal(t, conn.Stats(), map[string]drpcstats.Stats{
-		"/service.Service/Method1": {Read: 12 + 2, Written: 26 + 26},
-		"/service.Service/Method3": {Read: 6, Written: 26},
+	assert.Equal(t, counters(conn.Stats()), map[string]counts{
+		"/service.Service/Method1": {Read: 12 + 2, Written: 26 + 26, Calls: 2, MessagesRead: 1, MessagesWritten: 2, Errors: map[uint64]uint64{5: 1}},
+		"/service.Service/Method3": {Read: 6, Written: 26, Calls: 1, MessagesRead: 3, MessagesWritten: 1},
 	})
+
+	// the client streams are all finished once the calls have returned.
+	for _, stats := range conn.Stats() {
+		assert.Equal(t, stats.InFlight, 0)
+		assert.Equal(t, stats.Latency.Count(), stats.Calls)
+		assert.Equal(t, stats.WrittenSizes.Count(), stats.MessagesWritten)
+	}
+}
+
+// counts are the fields of the stats that are deterministic once the client
+// has received its responses.
+type counts struct {
+	Read, Written, Calls          uint64
+	MessagesRead, MessagesWritten uint64
+	Errors                        map[uint64]uint64
+}
+
+// counters returns the counts of the stats.
+func counters(stats map[string]drpcstats.Stats) map[string]counts {
+	out := make(map[string]counts, len(stats))
+	for rpc, s := range stats {
+		out[rpc] = counts{
+			Read:            s.Read,
+			Written:         s.Written,
+			Calls:           s.Calls,
+			MessagesRead:    s.MessagesRead,
+			MessagesWritten: s.MessagesWritten,
+			Errors:          s.Errors(),
+		}
+	}
+	return out
 }
@@ -114,6 +114,31 @@ func (DRPCCompatServiceDescription) Method(n int) (string, drpc.Encoding, drpc.R
 	}
 }
 
+func (DRPCCompatServiceDescription) MethodInfo(n int) (drpc.MethodInfo, bool) {
+	switch n {
+	case 0:
+		return drpc.MethodInfo{
+			Service:    "compat.CompatService",
+			Method:     "Method",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(Req) },
+			NewOut:     func() drpc.Message { return new(Resp) },
+			Descriptor: File_clientcompat_proto.Services().Get(0).Methods().Get(0),
+		}, true
+	case 1:
+		return drpc.MethodInfo{
+			Service:    "compat.CompatService",
+			Method:     "NoopMethod",
+			Kind:       drpc.MethodUnary,
+			NewIn:      func() drpc.Message { return new(Empty) },
+			NewOut:     func() drpc.Message { return new(Empty) },
+			Descriptor: File_clientcompat_proto.Services().Get(0).Methods().Get(1),
+		}, true
+	default:
+		return drpc.MethodInfo{}, false
+	}
+}
+
 func DRPCRegisterCompatService(mux drpc.Mux, impl DRPCCompatServiceServer) error {
 	return mux.Register(impl, DRPCCompatServiceDescription{})
 }
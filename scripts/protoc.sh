@@ -0,0 +1,27 @@
+#!/usr/bin/env bash
+
+# protoc.sh generates the go and drpc code for the proto files in the current
+# directory so that they are registered under the import path of the package,
+# like storj.io/drpc/drpchealth/health.proto, instead of their bare file names,
+# which can conflict with the files of other packages.
+
+set -e
+
+PACKAGE=$(go list -f '{{ .ImportPath }}' .)
+
+# link the current directory into a temporary tree laid out by import path
+ROOT=$(mktemp -d)
+trap 'rm -rf ${ROOT}' EXIT
+
+mkdir -p "${ROOT}/$(dirname "${PACKAGE}")"
+ln -s "$(pwd)" "${ROOT}/${PACKAGE}"
+
+FILES=()
+for FILE in "$@"; do
+	FILES+=("${PACKAGE}/${FILE}")
+done
+
+protoc -I"${ROOT}" \
+	--go_out=paths=source_relative:"${ROOT}" \
+	--go-drpc_out=paths=source_relative:"${ROOT}" \
+	"${FILES[@]}"